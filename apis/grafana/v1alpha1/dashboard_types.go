@@ -0,0 +1,97 @@
+/*
+Copyright The Searchlight Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Dashboard represents a Grafana dashboard owned and reconciled by the operator.
+//
+// Unlike Datasource (see datasource_webhook.go), Dashboard has no
+// webhook.CustomDefaulter/CustomValidator registered: no defaults are
+// applied to its spec, and the only check is the reconciler failing when
+// Model does not parse as a dashboard. Add one
+// here, mirroring datasourceWebhook, if Dashboard needs admission-time
+// checks.
+type Dashboard struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DashboardSpec   `json:"spec,omitempty"`
+	Status DashboardStatus `json:"status,omitempty"`
+}
+
+type DashboardSpec struct {
+	// Grafana is the name of the AppBinding (in the same namespace) that points to the
+	// target Grafana instance this Dashboard should be created against.
+	Grafana string `json:"grafana"`
+
+	FolderID int64 `json:"folderID,omitempty"`
+
+	// Model is the raw Grafana dashboard JSON model.
+	Model runtime.RawExtension `json:"model"`
+
+	// DriftPolicy controls what happens when the live dashboard in Grafana
+	// diverges from this spec. Defaults to DriftPolicyDetect.
+	// +optional
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// DriftCheckInterval overrides config.ResyncPeriod for how often this
+	// Dashboard's live state is compared against spec.
+	// +optional
+	DriftCheckInterval *metav1.Duration `json:"driftCheckInterval,omitempty"`
+}
+
+type DashboardStatus struct {
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// DashboardID is the numeric ID Grafana assigned this dashboard on creation.
+	DashboardID *int64 `json:"dashboardID,omitempty"`
+
+	// DashboardUID is the UID Grafana assigned this dashboard on creation.
+	// Deletes are keyed by UID rather than DashboardID, so this is required
+	// for the finalizer to actually remove the dashboard from Grafana.
+	DashboardUID *string `json:"dashboardUID,omitempty"`
+
+	// Drifted is true when the last drift check found the live dashboard
+	// diverging from spec.
+	// +optional
+	Drifted bool `json:"drifted,omitempty"`
+
+	// LastDriftCheckTime records when the live dashboard was last compared
+	// against spec.
+	// +optional
+	LastDriftCheckTime *metav1.Time `json:"lastDriftCheckTime,omitempty"`
+
+	// Drift is a JSON-patch-style summary of the last detected divergence
+	// between the live dashboard and spec. Empty when no drift was found.
+	// +optional
+	Drift string `json:"drift,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DashboardList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Dashboard `json:"items"`
+}
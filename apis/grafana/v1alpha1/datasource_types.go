@@ -0,0 +1,131 @@
+/*
+Copyright The Searchlight Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Datasource represents a Grafana datasource owned and reconciled by the operator.
+type Datasource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DatasourceSpec   `json:"spec,omitempty"`
+	Status DatasourceStatus `json:"status,omitempty"`
+}
+
+type DatasourceSpec struct {
+	// Grafana is the name of the AppBinding (in the same namespace) that points to the
+	// target Grafana instance this Datasource should be created against.
+	Grafana string `json:"grafana"`
+
+	OrgID     int64  `json:"orgID,omitempty"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Access    string `json:"access,omitempty"`
+	URL       string `json:"url"`
+	IsDefault bool   `json:"isDefault,omitempty"`
+
+	// SecretRef resolves this datasource's authentication from Secrets instead of
+	// storing credentials inline on the spec. Every field is optional; set only
+	// the ones relevant to Access/Type.
+	// +optional
+	SecretRef *DatasourceSecretRef `json:"secretRef,omitempty"`
+
+	// DriftPolicy controls what happens when the live datasource in Grafana
+	// diverges from this spec. Defaults to DriftPolicyDetect.
+	// +optional
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// DriftCheckInterval overrides config.ResyncPeriod for how often this
+	// Datasource's live state is compared against spec.
+	// +optional
+	DriftCheckInterval *metav1.Duration `json:"driftCheckInterval,omitempty"`
+}
+
+// DatasourceSecretRef selects the Secrets that back a Datasource's authentication.
+type DatasourceSecretRef struct {
+	// BasicAuthUserSecretRef selects the key holding the basic-auth username.
+	// +optional
+	BasicAuthUserSecretRef *SecretKeySelector `json:"basicAuthUserSecretRef,omitempty"`
+	// BasicAuthPasswordSecretRef selects the key holding the basic-auth password.
+	// +optional
+	BasicAuthPasswordSecretRef *SecretKeySelector `json:"basicAuthPasswordSecretRef,omitempty"`
+
+	// TLSClientCertSecretRef selects the key holding the TLS client certificate (PEM).
+	// +optional
+	TLSClientCertSecretRef *SecretKeySelector `json:"tlsClientCertSecretRef,omitempty"`
+	// TLSClientKeySecretRef selects the key holding the TLS client private key (PEM).
+	// +optional
+	TLSClientKeySecretRef *SecretKeySelector `json:"tlsClientKeySecretRef,omitempty"`
+	// TLSCASecretRef selects the key holding the CA bundle (PEM) used to verify the server.
+	// +optional
+	TLSCASecretRef *SecretKeySelector `json:"tlsCASecretRef,omitempty"`
+
+	// BearerTokenSecretRef selects the key holding a bearer token.
+	// +optional
+	BearerTokenSecretRef *SecretKeySelector `json:"bearerTokenSecretRef,omitempty"`
+
+	// HTTPHeaderSecretRefs selects keys holding extra HTTP headers to send with
+	// every request, keyed by header name. This only covers auth schemes a
+	// static header value is sufficient for (e.g. a pre-shared API key); it
+	// cannot implement a signing scheme such as SigV4 or a GCE service account
+	// key, which require computing the header(s) per request.
+	// +optional
+	HTTPHeaderSecretRefs map[string]SecretKeySelector `json:"httpHeaderSecretRefs,omitempty"`
+}
+
+// SecretKeySelector selects a single key of a Secret in the referencing object's
+// namespace.
+type SecretKeySelector struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+type DatasourceStatus struct {
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// DatasourceID is the numeric ID Grafana assigned this datasource on creation.
+	DatasourceID *int64 `json:"datasourceID,omitempty"`
+
+	// Drifted is true when the last drift check found the live datasource
+	// diverging from spec.
+	// +optional
+	Drifted bool `json:"drifted,omitempty"`
+
+	// LastDriftCheckTime records when the live datasource was last compared
+	// against spec.
+	// +optional
+	LastDriftCheckTime *metav1.Time `json:"lastDriftCheckTime,omitempty"`
+
+	// Drift is a JSON-patch-style summary of the last detected divergence
+	// between the live datasource and spec. Empty when no drift was found.
+	// +optional
+	Drift string `json:"drift,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DatasourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Datasource `json:"items"`
+}
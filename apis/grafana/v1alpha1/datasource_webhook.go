@@ -0,0 +1,83 @@
+/*
+Copyright The Searchlight Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// datasourceWebhook implements webhook.CustomDefaulter and
+// webhook.CustomValidator for Datasource. It is the controller-runtime
+// counterpart of the mutating/validating webhook configs that pkg/controller
+// still wires up by hand.
+type datasourceWebhook struct{}
+
+func (datasourceWebhook) Default(_ context.Context, obj runtime.Object) error {
+	ds, ok := obj.(*Datasource)
+	if !ok {
+		return errors.Errorf("expected a Datasource, got %T", obj)
+	}
+	if ds.Spec.Access == "" {
+		ds.Spec.Access = "proxy"
+	}
+	return nil
+}
+
+func (datasourceWebhook) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, validateDatasource(obj)
+}
+
+func (datasourceWebhook) ValidateUpdate(_ context.Context, _, obj runtime.Object) (admission.Warnings, error) {
+	return nil, validateDatasource(obj)
+}
+
+func (datasourceWebhook) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateDatasource(obj runtime.Object) error {
+	ds, ok := obj.(*Datasource)
+	if !ok {
+		return errors.Errorf("expected a Datasource, got %T", obj)
+	}
+	if ds.Spec.Grafana == "" {
+		return errors.New("spec.grafana must be set to an AppBinding name")
+	}
+	if ds.Spec.Name == "" {
+		return errors.New("spec.name must not be empty")
+	}
+	return nil
+}
+
+// SetupWebhookWithManager registers the defaulting and validating webhooks for
+// Datasource with mgr.
+func (d *Datasource) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(d).
+		WithDefaulter(datasourceWebhook{}).
+		WithValidator(datasourceWebhook{}).
+		Complete()
+}
+
+var _ webhook.CustomDefaulter = datasourceWebhook{}
+var _ webhook.CustomValidator = datasourceWebhook{}
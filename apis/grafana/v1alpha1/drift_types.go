@@ -0,0 +1,32 @@
+/*
+Copyright The Searchlight Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// DriftPolicy controls what a reconciler does when the live Grafana object
+// diverges from a Dashboard/Datasource's rendered spec.
+type DriftPolicy string
+
+const (
+	// DriftPolicyDetect only records the divergence on status and emits a
+	// DriftDetected event; the live object is left untouched. This is the
+	// default when DriftPolicy is unset.
+	DriftPolicyDetect DriftPolicy = "Detect"
+
+	// DriftPolicyEnforce re-pushes the CR's desired state over the live
+	// object, undoing any out-of-band change.
+	DriftPolicyEnforce DriftPolicy = "Enforce"
+)
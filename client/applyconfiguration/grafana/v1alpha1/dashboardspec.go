@@ -0,0 +1,69 @@
+/*
+Copyright The Searchlight Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Hand-maintained to mirror the shape applyconfiguration-gen would emit for
+// the Dashboard/Datasource types, since this repo does not wire up that
+// generator. Keep in sync with apis/grafana/v1alpha1 by hand until it does.
+
+package v1alpha1
+
+import (
+	api "go.searchlight.dev/grafana-operator/apis/grafana/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DashboardSpecApplyConfiguration represents a declarative configuration of the DashboardSpec type for use
+// with apply.
+type DashboardSpecApplyConfiguration struct {
+	Grafana            *string               `json:"grafana,omitempty"`
+	FolderID           *int64                `json:"folderID,omitempty"`
+	Model              *runtime.RawExtension `json:"model,omitempty"`
+	DriftPolicy        *api.DriftPolicy      `json:"driftPolicy,omitempty"`
+	DriftCheckInterval *metav1.Duration      `json:"driftCheckInterval,omitempty"`
+}
+
+// DashboardSpec constructs a declarative configuration of the DashboardSpec type for use with
+// apply.
+func DashboardSpec() *DashboardSpecApplyConfiguration {
+	return &DashboardSpecApplyConfiguration{}
+}
+
+func (b *DashboardSpecApplyConfiguration) WithGrafana(value string) *DashboardSpecApplyConfiguration {
+	b.Grafana = &value
+	return b
+}
+
+func (b *DashboardSpecApplyConfiguration) WithFolderID(value int64) *DashboardSpecApplyConfiguration {
+	b.FolderID = &value
+	return b
+}
+
+func (b *DashboardSpecApplyConfiguration) WithModel(value runtime.RawExtension) *DashboardSpecApplyConfiguration {
+	b.Model = &value
+	return b
+}
+
+func (b *DashboardSpecApplyConfiguration) WithDriftPolicy(value api.DriftPolicy) *DashboardSpecApplyConfiguration {
+	b.DriftPolicy = &value
+	return b
+}
+
+func (b *DashboardSpecApplyConfiguration) WithDriftCheckInterval(value metav1.Duration) *DashboardSpecApplyConfiguration {
+	b.DriftCheckInterval = &value
+	return b
+}
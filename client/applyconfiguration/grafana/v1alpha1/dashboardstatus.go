@@ -0,0 +1,72 @@
+/*
+Copyright The Searchlight Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Hand-maintained to mirror the shape applyconfiguration-gen would emit for
+// the Dashboard/Datasource types, since this repo does not wire up that
+// generator. Keep in sync with apis/grafana/v1alpha1 by hand until it does.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DashboardStatusApplyConfiguration represents a declarative configuration of the DashboardStatus type for use
+// with apply.
+type DashboardStatusApplyConfiguration struct {
+	ObservedGeneration *int64       `json:"observedGeneration,omitempty"`
+	DashboardID        *int64       `json:"dashboardID,omitempty"`
+	DashboardUID       *string      `json:"dashboardUID,omitempty"`
+	Drifted            *bool        `json:"drifted,omitempty"`
+	LastDriftCheckTime *metav1.Time `json:"lastDriftCheckTime,omitempty"`
+	Drift              *string      `json:"drift,omitempty"`
+}
+
+// DashboardStatus constructs a declarative configuration of the DashboardStatus type for use with
+// apply.
+func DashboardStatus() *DashboardStatusApplyConfiguration {
+	return &DashboardStatusApplyConfiguration{}
+}
+
+func (b *DashboardStatusApplyConfiguration) WithObservedGeneration(value int64) *DashboardStatusApplyConfiguration {
+	b.ObservedGeneration = &value
+	return b
+}
+
+func (b *DashboardStatusApplyConfiguration) WithDashboardID(value int64) *DashboardStatusApplyConfiguration {
+	b.DashboardID = &value
+	return b
+}
+
+func (b *DashboardStatusApplyConfiguration) WithDashboardUID(value string) *DashboardStatusApplyConfiguration {
+	b.DashboardUID = &value
+	return b
+}
+
+func (b *DashboardStatusApplyConfiguration) WithDrifted(value bool) *DashboardStatusApplyConfiguration {
+	b.Drifted = &value
+	return b
+}
+
+func (b *DashboardStatusApplyConfiguration) WithLastDriftCheckTime(value metav1.Time) *DashboardStatusApplyConfiguration {
+	b.LastDriftCheckTime = &value
+	return b
+}
+
+func (b *DashboardStatusApplyConfiguration) WithDrift(value string) *DashboardStatusApplyConfiguration {
+	b.Drift = &value
+	return b
+}
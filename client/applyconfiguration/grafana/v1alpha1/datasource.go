@@ -0,0 +1,118 @@
+/*
+Copyright The Searchlight Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Hand-maintained to mirror the shape applyconfiguration-gen would emit for
+// the Dashboard/Datasource types, since this repo does not wire up that
+// generator. Keep in sync with apis/grafana/v1alpha1 by hand until it does.
+
+package v1alpha1
+
+import (
+	api "go.searchlight.dev/grafana-operator/apis/grafana/v1alpha1"
+
+	types "k8s.io/apimachinery/pkg/types"
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// DatasourceApplyConfiguration represents a declarative configuration of the Datasource type for use
+// with apply.
+type DatasourceApplyConfiguration struct {
+	v1.TypeMetaApplyConfiguration    `json:",inline"`
+	*v1.ObjectMetaApplyConfiguration `json:"metadata,omitempty"`
+	Spec                             *DatasourceSpecApplyConfiguration   `json:"spec,omitempty"`
+	Status                           *DatasourceStatusApplyConfiguration `json:"status,omitempty"`
+}
+
+// Datasource constructs a declarative configuration of the Datasource type for use with
+// apply.
+func Datasource(name, namespace string) *DatasourceApplyConfiguration {
+	b := &DatasourceApplyConfiguration{}
+	b.WithName(name)
+	b.WithNamespace(namespace)
+	b.WithKind(api.ResourceKindDatasource)
+	b.WithAPIVersion(api.SchemeGroupVersion.String())
+	return b
+}
+
+func (b *DatasourceApplyConfiguration) WithKind(value string) *DatasourceApplyConfiguration {
+	b.TypeMetaApplyConfiguration.Kind = &value
+	return b
+}
+
+func (b *DatasourceApplyConfiguration) WithAPIVersion(value string) *DatasourceApplyConfiguration {
+	b.TypeMetaApplyConfiguration.APIVersion = &value
+	return b
+}
+
+func (b *DatasourceApplyConfiguration) WithName(value string) *DatasourceApplyConfiguration {
+	b.ensureObjectMetaApplyConfiguration()
+	b.ObjectMetaApplyConfiguration.Name = &value
+	return b
+}
+
+func (b *DatasourceApplyConfiguration) WithNamespace(value string) *DatasourceApplyConfiguration {
+	b.ensureObjectMetaApplyConfiguration()
+	b.ObjectMetaApplyConfiguration.Namespace = &value
+	return b
+}
+
+func (b *DatasourceApplyConfiguration) WithResourceVersion(value string) *DatasourceApplyConfiguration {
+	b.ensureObjectMetaApplyConfiguration()
+	b.ObjectMetaApplyConfiguration.ResourceVersion = &value
+	return b
+}
+
+func (b *DatasourceApplyConfiguration) WithUID(value types.UID) *DatasourceApplyConfiguration {
+	b.ensureObjectMetaApplyConfiguration()
+	b.ObjectMetaApplyConfiguration.UID = &value
+	return b
+}
+
+func (b *DatasourceApplyConfiguration) WithFinalizers(values ...string) *DatasourceApplyConfiguration {
+	b.ensureObjectMetaApplyConfiguration()
+	for i := range values {
+		b.ObjectMetaApplyConfiguration.Finalizers = append(b.ObjectMetaApplyConfiguration.Finalizers, values[i])
+	}
+	return b
+}
+
+func (b *DatasourceApplyConfiguration) WithSpec(value *DatasourceSpecApplyConfiguration) *DatasourceApplyConfiguration {
+	b.Spec = value
+	return b
+}
+
+func (b *DatasourceApplyConfiguration) WithStatus(value *DatasourceStatusApplyConfiguration) *DatasourceApplyConfiguration {
+	b.Status = value
+	return b
+}
+
+func (b *DatasourceApplyConfiguration) ensureObjectMetaApplyConfiguration() {
+	if b.ObjectMetaApplyConfiguration == nil {
+		b.ObjectMetaApplyConfiguration = &v1.ObjectMetaApplyConfiguration{}
+	}
+}
+
+// GetName retrieves the value of the Name field in the declarative configuration.
+func (b *DatasourceApplyConfiguration) GetName() *string {
+	b.ensureObjectMetaApplyConfiguration()
+	return b.ObjectMetaApplyConfiguration.Name
+}
+
+// GetNamespace retrieves the value of the Namespace field in the declarative configuration.
+func (b *DatasourceApplyConfiguration) GetNamespace() *string {
+	b.ensureObjectMetaApplyConfiguration()
+	return b.ObjectMetaApplyConfiguration.Namespace
+}
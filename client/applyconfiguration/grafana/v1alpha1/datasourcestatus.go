@@ -0,0 +1,66 @@
+/*
+Copyright The Searchlight Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Hand-maintained to mirror the shape applyconfiguration-gen would emit for
+// the Dashboard/Datasource types, since this repo does not wire up that
+// generator. Keep in sync with apis/grafana/v1alpha1 by hand until it does.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DatasourceStatusApplyConfiguration represents a declarative configuration of the DatasourceStatus type for use
+// with apply.
+type DatasourceStatusApplyConfiguration struct {
+	ObservedGeneration *int64       `json:"observedGeneration,omitempty"`
+	DatasourceID       *int64       `json:"datasourceID,omitempty"`
+	Drifted            *bool        `json:"drifted,omitempty"`
+	LastDriftCheckTime *metav1.Time `json:"lastDriftCheckTime,omitempty"`
+	Drift              *string      `json:"drift,omitempty"`
+}
+
+// DatasourceStatus constructs a declarative configuration of the DatasourceStatus type for use with
+// apply.
+func DatasourceStatus() *DatasourceStatusApplyConfiguration {
+	return &DatasourceStatusApplyConfiguration{}
+}
+
+func (b *DatasourceStatusApplyConfiguration) WithObservedGeneration(value int64) *DatasourceStatusApplyConfiguration {
+	b.ObservedGeneration = &value
+	return b
+}
+
+func (b *DatasourceStatusApplyConfiguration) WithDatasourceID(value int64) *DatasourceStatusApplyConfiguration {
+	b.DatasourceID = &value
+	return b
+}
+
+func (b *DatasourceStatusApplyConfiguration) WithDrifted(value bool) *DatasourceStatusApplyConfiguration {
+	b.Drifted = &value
+	return b
+}
+
+func (b *DatasourceStatusApplyConfiguration) WithLastDriftCheckTime(value metav1.Time) *DatasourceStatusApplyConfiguration {
+	b.LastDriftCheckTime = &value
+	return b
+}
+
+func (b *DatasourceStatusApplyConfiguration) WithDrift(value string) *DatasourceStatusApplyConfiguration {
+	b.Drift = &value
+	return b
+}
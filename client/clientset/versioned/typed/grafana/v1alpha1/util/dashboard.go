@@ -17,76 +17,108 @@ limitations under the License.
 package util
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
+	"reflect"
 
 	api "go.searchlight.dev/grafana-operator/apis/grafana/v1alpha1"
+	applyv1alpha1 "go.searchlight.dev/grafana-operator/client/applyconfiguration/grafana/v1alpha1"
 	cs "go.searchlight.dev/grafana-operator/client/clientset/versioned/typed/grafana/v1alpha1"
 
-	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
 	kerr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	kutil "kmodules.xyz/client-go"
 )
 
-func CreateOrPatchDashboard(c cs.GrafanaV1alpha1Interface, meta metav1.ObjectMeta, transform func(alert *api.Dashboard) *api.Dashboard) (*api.Dashboard, kutil.VerbType, error) {
-	cur, err := c.Dashboards(meta.Namespace).Get(meta.Name, metav1.GetOptions{})
+// fieldManager is the default field manager the operator identifies itself
+// with when callers don't set one in their PatchOptions.
+const fieldManager = "grafana-operator"
+
+func CreateOrPatchDashboard(ctx context.Context, c cs.GrafanaV1alpha1Interface, meta metav1.ObjectMeta, transform func(alert *api.Dashboard) *api.Dashboard, opts metav1.PatchOptions) (*api.Dashboard, kutil.VerbType, error) {
+	cur, err := c.Dashboards(meta.Namespace).Get(ctx, meta.Name, metav1.GetOptions{})
 	if kerr.IsNotFound(err) {
 		glog.V(3).Infof("Creating Dashboard %s/%s.", meta.Namespace, meta.Name)
-		out, err := c.Dashboards(meta.Namespace).Create(transform(&api.Dashboard{
+		out, err := c.Dashboards(meta.Namespace).Create(ctx, transform(&api.Dashboard{
 			TypeMeta: metav1.TypeMeta{
 				Kind:       api.ResourceKindDashboard,
 				APIVersion: api.SchemeGroupVersion.String(),
 			},
 			ObjectMeta: meta,
-		}))
+		}), metav1.CreateOptions{})
 		return out, kutil.VerbCreated, err
 	} else if err != nil {
 		return nil, kutil.VerbUnchanged, err
 	}
-	return PatchDashboard(c, cur, transform)
+	return PatchDashboard(ctx, c, cur, transform, opts)
 }
 
-func PatchDashboard(c cs.GrafanaV1alpha1Interface, cur *api.Dashboard, transform func(*api.Dashboard) *api.Dashboard) (*api.Dashboard, kutil.VerbType, error) {
-	return PatchDashboardObject(c, cur, transform(cur.DeepCopy()))
+func PatchDashboard(ctx context.Context, c cs.GrafanaV1alpha1Interface, cur *api.Dashboard, transform func(*api.Dashboard) *api.Dashboard, opts metav1.PatchOptions) (*api.Dashboard, kutil.VerbType, error) {
+	return PatchDashboardObject(ctx, c, cur, transform(cur.DeepCopy()), opts)
 }
 
-func PatchDashboardObject(c cs.GrafanaV1alpha1Interface, cur, mod *api.Dashboard) (*api.Dashboard, kutil.VerbType, error) {
-	curJson, err := json.Marshal(cur)
+// PatchDashboardObject reconciles cur towards mod using server-side apply
+// instead of a client-side JSON merge patch: the operator only ever claims
+// the fields it renders, so it can't clobber fields owned by another field
+// manager, and two racing reconciles converge on the same result instead of
+// one silently overwriting the other's update.
+func PatchDashboardObject(ctx context.Context, c cs.GrafanaV1alpha1Interface, cur, mod *api.Dashboard, opts metav1.PatchOptions) (*api.Dashboard, kutil.VerbType, error) {
+	applyConfig := applyv1alpha1.Dashboard(mod.Name, mod.Namespace).
+		WithFinalizers(mod.Finalizers...).
+		WithSpec(toDashboardSpecApplyConfiguration(&mod.Spec))
+
+	glog.V(3).Infof("Applying Dashboard %s/%s.", cur.Namespace, cur.Name)
+	out, err := c.Dashboards(cur.Namespace).Apply(ctx, applyConfig, toApplyOptions(opts))
 	if err != nil {
 		return nil, kutil.VerbUnchanged, err
 	}
+	if reflect.DeepEqual(out.Spec, cur.Spec) {
+		return out, kutil.VerbUnchanged, nil
+	}
+	return out, kutil.VerbPatched, nil
+}
 
-	modJson, err := json.Marshal(mod)
-	if err != nil {
-		return nil, kutil.VerbUnchanged, err
+// toApplyOptions adapts a metav1.PatchOptions, the options type the rest of
+// this package's callers already pass around, into the metav1.ApplyOptions
+// server-side apply needs. A caller that leaves FieldManager empty gets the
+// operator's own field manager rather than an empty string.
+func toApplyOptions(opts metav1.PatchOptions) metav1.ApplyOptions {
+	fm := opts.FieldManager
+	if fm == "" {
+		fm = fieldManager
+	}
+	force := opts.Force != nil && *opts.Force
+	return metav1.ApplyOptions{
+		DryRun:       opts.DryRun,
+		Force:        force,
+		FieldManager: fm,
 	}
+}
 
-	patch, err := jsonpatch.CreateMergePatch(curJson, modJson)
-	if err != nil {
-		return nil, kutil.VerbUnchanged, err
+func toDashboardSpecApplyConfiguration(spec *api.DashboardSpec) *applyv1alpha1.DashboardSpecApplyConfiguration {
+	out := applyv1alpha1.DashboardSpec().
+		WithGrafana(spec.Grafana).
+		WithFolderID(spec.FolderID).
+		WithModel(spec.Model)
+	if spec.DriftPolicy != "" {
+		out = out.WithDriftPolicy(spec.DriftPolicy)
 	}
-	if len(patch) == 0 || string(patch) == "{}" {
-		return cur, kutil.VerbUnchanged, nil
+	if spec.DriftCheckInterval != nil {
+		out = out.WithDriftCheckInterval(*spec.DriftCheckInterval)
 	}
-	glog.V(3).Infof("Patching Dashboard %s/%s with %s.", cur.Namespace, cur.Name, string(patch))
-	out, err := c.Dashboards(cur.Namespace).Patch(cur.Name, types.MergePatchType, patch)
-	return out, kutil.VerbPatched, err
+	return out
 }
 
-func TryUpdateDashboard(c cs.GrafanaV1alpha1Interface, meta metav1.ObjectMeta, transform func(*api.Dashboard) *api.Dashboard) (result *api.Dashboard, err error) {
+func TryUpdateDashboard(ctx context.Context, c cs.GrafanaV1alpha1Interface, meta metav1.ObjectMeta, transform func(*api.Dashboard) *api.Dashboard) (result *api.Dashboard, err error) {
 	attempt := 0
 	err = wait.PollImmediate(kutil.RetryInterval, kutil.RetryTimeout, func() (bool, error) {
 		attempt++
-		cur, e2 := c.Dashboards(meta.Namespace).Get(meta.Name, metav1.GetOptions{})
+		cur, e2 := c.Dashboards(meta.Namespace).Get(ctx, meta.Name, metav1.GetOptions{})
 		if kerr.IsNotFound(e2) {
 			return false, e2
 		} else if e2 == nil {
-			result, e2 = c.Dashboards(cur.Namespace).Update(transform(cur.DeepCopy()))
+			result, e2 = c.Dashboards(cur.Namespace).Update(ctx, transform(cur.DeepCopy()), metav1.UpdateOptions{})
 			return e2 == nil, nil
 		}
 		glog.Errorf("Attempt %d failed to update Dashboard %s/%s due to %v.", attempt, cur.Namespace, cur.Name, e2)
@@ -99,50 +131,33 @@ func TryUpdateDashboard(c cs.GrafanaV1alpha1Interface, meta metav1.ObjectMeta, t
 	return
 }
 
-func UpdateDashboardStatus(
-	c cs.GrafanaV1alpha1Interface,
-	in *api.Dashboard,
-	transform func(*api.DashboardStatus) *api.DashboardStatus,
-) (result *api.Dashboard, err error) {
-	apply := func(x *api.Dashboard, copy bool) *api.Dashboard {
-		out := &api.Dashboard{
-			TypeMeta:   x.TypeMeta,
-			ObjectMeta: x.ObjectMeta,
-			Spec:       x.Spec,
-		}
-		if copy {
-			out.Status = *transform(in.Status.DeepCopy())
-		} else {
-			out.Status = *transform(&in.Status)
-		}
-		return out
-	}
-
-	attempt := 0
-	cur := in.DeepCopy()
-	err = wait.PollImmediate(kutil.RetryInterval, kutil.RetryTimeout, func() (bool, error) {
-		attempt++
-		var e2 error
-		result, e2 = c.Dashboards(in.Namespace).UpdateStatus(apply(cur, false))
-		if kerr.IsConflict(e2) {
-			latest, e3 := c.Dashboards(in.Namespace).Get(in.Name, metav1.GetOptions{})
-			switch {
-			case e3 == nil:
-				cur = latest
-				return false, nil
-			case kutil.IsRequestRetryable(e3):
-				return false, nil
-			default:
-				return false, e3
-			}
-		} else if err != nil && !kutil.IsRequestRetryable(e2) {
-			return false, e2
-		}
-		return e2 == nil, nil
+// UpdateDashboardStatus applies only the status subresource via server-side
+// apply. Unlike the hand-reconstructed full-object UpdateStatus this used to
+// be, it never touches ObjectMeta, so it can't clobber a finalizer another
+// reconcile added to the object while this call was in flight or retrying.
+func UpdateDashboardStatus(ctx context.Context, c cs.GrafanaV1alpha1Interface, in *api.Dashboard, transform func(*api.DashboardStatus) *api.DashboardStatus) (*api.Dashboard, error) {
+	status := transform(in.Status.DeepCopy())
+	applyConfig := applyv1alpha1.Dashboard(in.Name, in.Namespace).
+		WithStatus(toDashboardStatusApplyConfiguration(status))
+	return c.Dashboards(in.Namespace).ApplyStatus(ctx, applyConfig, metav1.ApplyOptions{
+		Force:        true,
+		FieldManager: fieldManager,
 	})
+}
 
-	if err != nil {
-		err = fmt.Errorf("failed to update status of Dashboard %s/%s after %d attempts due to %v", in.Namespace, in.Name, attempt, err)
+func toDashboardStatusApplyConfiguration(status *api.DashboardStatus) *applyv1alpha1.DashboardStatusApplyConfiguration {
+	out := applyv1alpha1.DashboardStatus().
+		WithObservedGeneration(status.ObservedGeneration).
+		WithDrifted(status.Drifted).
+		WithDrift(status.Drift)
+	if status.DashboardID != nil {
+		out = out.WithDashboardID(*status.DashboardID)
 	}
-	return
-}
\ No newline at end of file
+	if status.DashboardUID != nil {
+		out = out.WithDashboardUID(*status.DashboardUID)
+	}
+	if status.LastDriftCheckTime != nil {
+		out = out.WithLastDriftCheckTime(*status.LastDriftCheckTime)
+	}
+	return out
+}
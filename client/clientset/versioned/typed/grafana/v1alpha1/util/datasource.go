@@ -0,0 +1,145 @@
+/*
+Copyright The Searchlight Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"reflect"
+
+	api "go.searchlight.dev/grafana-operator/apis/grafana/v1alpha1"
+	applyv1alpha1 "go.searchlight.dev/grafana-operator/client/applyconfiguration/grafana/v1alpha1"
+	cs "go.searchlight.dev/grafana-operator/client/clientset/versioned/typed/grafana/v1alpha1"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kutil "kmodules.xyz/client-go"
+)
+
+func CreateOrPatchDatasource(ctx context.Context, c cs.GrafanaV1alpha1Interface, meta metav1.ObjectMeta, transform func(alert *api.Datasource) *api.Datasource, opts metav1.PatchOptions) (*api.Datasource, kutil.VerbType, error) {
+	cur, err := c.Datasources(meta.Namespace).Get(ctx, meta.Name, metav1.GetOptions{})
+	if kerr.IsNotFound(err) {
+		glog.V(3).Infof("Creating Datasource %s/%s.", meta.Namespace, meta.Name)
+		out, err := c.Datasources(meta.Namespace).Create(ctx, transform(&api.Datasource{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       api.ResourceKindDatasource,
+				APIVersion: api.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: meta,
+		}), metav1.CreateOptions{})
+		return out, kutil.VerbCreated, err
+	} else if err != nil {
+		return nil, kutil.VerbUnchanged, err
+	}
+	return PatchDatasource(ctx, c, cur, transform, opts)
+}
+
+func PatchDatasource(ctx context.Context, c cs.GrafanaV1alpha1Interface, cur *api.Datasource, transform func(*api.Datasource) *api.Datasource, opts metav1.PatchOptions) (*api.Datasource, kutil.VerbType, error) {
+	return PatchDatasourceObject(ctx, c, cur, transform(cur.DeepCopy()), opts)
+}
+
+// PatchDatasourceObject reconciles cur towards mod using server-side apply,
+// the same way PatchDashboardObject does: the operator only claims the
+// fields it renders, so a racing reconcile or a user's own edit to a field
+// the operator doesn't manage survives instead of being clobbered.
+func PatchDatasourceObject(ctx context.Context, c cs.GrafanaV1alpha1Interface, cur, mod *api.Datasource, opts metav1.PatchOptions) (*api.Datasource, kutil.VerbType, error) {
+	applyConfig := applyv1alpha1.Datasource(mod.Name, mod.Namespace).
+		WithFinalizers(mod.Finalizers...).
+		WithSpec(toDatasourceSpecApplyConfiguration(&mod.Spec))
+
+	glog.V(3).Infof("Applying Datasource %s/%s.", cur.Namespace, cur.Name)
+	out, err := c.Datasources(cur.Namespace).Apply(ctx, applyConfig, toApplyOptions(opts))
+	if err != nil {
+		return nil, kutil.VerbUnchanged, err
+	}
+	if reflect.DeepEqual(out.Spec, cur.Spec) {
+		return out, kutil.VerbUnchanged, nil
+	}
+	return out, kutil.VerbPatched, nil
+}
+
+func toDatasourceSpecApplyConfiguration(spec *api.DatasourceSpec) *applyv1alpha1.DatasourceSpecApplyConfiguration {
+	out := applyv1alpha1.DatasourceSpec().
+		WithGrafana(spec.Grafana).
+		WithOrgID(spec.OrgID).
+		WithName(spec.Name).
+		WithType(spec.Type).
+		WithAccess(spec.Access).
+		WithURL(spec.URL).
+		WithIsDefault(spec.IsDefault)
+	if spec.SecretRef != nil {
+		out = out.WithSecretRef(*spec.SecretRef)
+	}
+	if spec.DriftPolicy != "" {
+		out = out.WithDriftPolicy(spec.DriftPolicy)
+	}
+	if spec.DriftCheckInterval != nil {
+		out = out.WithDriftCheckInterval(*spec.DriftCheckInterval)
+	}
+	return out
+}
+
+func TryUpdateDatasource(ctx context.Context, c cs.GrafanaV1alpha1Interface, meta metav1.ObjectMeta, transform func(*api.Datasource) *api.Datasource) (result *api.Datasource, err error) {
+	attempt := 0
+	err = wait.PollImmediate(kutil.RetryInterval, kutil.RetryTimeout, func() (bool, error) {
+		attempt++
+		cur, e2 := c.Datasources(meta.Namespace).Get(ctx, meta.Name, metav1.GetOptions{})
+		if kerr.IsNotFound(e2) {
+			return false, e2
+		} else if e2 == nil {
+			result, e2 = c.Datasources(cur.Namespace).Update(ctx, transform(cur.DeepCopy()), metav1.UpdateOptions{})
+			return e2 == nil, nil
+		}
+		glog.Errorf("Attempt %d failed to update Datasource %s/%s due to %v.", attempt, cur.Namespace, cur.Name, e2)
+		return false, nil
+	})
+
+	if err != nil {
+		err = errors.Errorf("failed to update Datasource %s/%s after %d attempts due to %v", meta.Namespace, meta.Name, attempt, err)
+	}
+	return
+}
+
+// UpdateDatasourceStatus applies only the status subresource via server-side
+// apply, for the same reason UpdateDashboardStatus does: it never touches
+// ObjectMeta, so it can't clobber a finalizer added to the object by another
+// reconcile while this call was in flight or retrying.
+func UpdateDatasourceStatus(ctx context.Context, c cs.GrafanaV1alpha1Interface, in *api.Datasource, transform func(*api.DatasourceStatus) *api.DatasourceStatus) (*api.Datasource, error) {
+	status := transform(in.Status.DeepCopy())
+	applyConfig := applyv1alpha1.Datasource(in.Name, in.Namespace).
+		WithStatus(toDatasourceStatusApplyConfiguration(status))
+	return c.Datasources(in.Namespace).ApplyStatus(ctx, applyConfig, metav1.ApplyOptions{
+		Force:        true,
+		FieldManager: fieldManager,
+	})
+}
+
+func toDatasourceStatusApplyConfiguration(status *api.DatasourceStatus) *applyv1alpha1.DatasourceStatusApplyConfiguration {
+	out := applyv1alpha1.DatasourceStatus().
+		WithObservedGeneration(status.ObservedGeneration).
+		WithDrifted(status.Drifted).
+		WithDrift(status.Drift)
+	if status.DatasourceID != nil {
+		out = out.WithDatasourceID(*status.DatasourceID)
+	}
+	if status.LastDriftCheckTime != nil {
+		out = out.WithLastDriftCheckTime(*status.LastDriftCheckTime)
+	}
+	return out
+}
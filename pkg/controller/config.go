@@ -26,6 +26,7 @@ import (
 	pcm "github.com/prometheus-operator/prometheus-operator/pkg/client/versioned/typed/monitoring/v1"
 	core "k8s.io/api/core/v1"
 	crd_cs "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -47,6 +48,14 @@ type config struct {
 	ResyncPeriod            time.Duration
 	EnableValidatingWebhook bool
 	EnableMutatingWebhook   bool
+
+	// ForceConflictResolution makes the server-side apply calls used to patch
+	// Dashboard/Datasource objects take ownership of fields another field
+	// manager currently holds, instead of failing with a conflict. Off by
+	// default: a conflict usually means another controller or a user is
+	// managing that field on purpose, and silently stealing it is worse than
+	// surfacing the conflict as a retryable error.
+	ForceConflictResolution bool
 }
 
 type Config struct {
@@ -86,6 +95,7 @@ func (c *Config) New() (*GrafanaController, error) {
 		extInformerFactory: grafanainformers.NewSharedInformerFactory(c.ExtClient, c.ResyncPeriod),
 		recorder:           eventer.NewEventRecorder(c.KubeClient, "grafana-operator"),
 	}
+	ctrl.grafanaPool = newGrafanaClientPool(ctrl)
 
 	if err := ctrl.ensureCustomResourceDefinitions(); err != nil {
 		return nil, err
@@ -107,5 +117,10 @@ func (c *Config) New() (*GrafanaController, error) {
 	// For Datasource
 	ctrl.initDatasourceWatcher()
 
+	// Periodically evict clients whose AppBinding was deleted and drop ones
+	// that have gone unreachable, so a later reference to the same name
+	// rebuilds from scratch instead of reusing a stale client.
+	go ctrl.grafanaPool.StartHealthChecks(c.ResyncPeriod, wait.NeverStop)
+
 	return ctrl, nil
 }
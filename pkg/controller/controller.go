@@ -0,0 +1,80 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package controller is the informer/lister/workqueue based reconciler that
+// currently runs in production for both Dashboard and Datasource. See
+// pkg/controllers' package doc for why its controller-runtime based
+// replacement exists alongside this package instead of having replaced it.
+package controller
+
+import (
+	cs "go.openviz.dev/grafana-operator/client/clientset/versioned"
+	grafanainformers "go.openviz.dev/grafana-operator/client/informers/externalversions"
+	listers "go.openviz.dev/grafana-operator/client/listers/grafana/v1alpha1"
+
+	pcm "github.com/prometheus-operator/prometheus-operator/pkg/client/versioned/typed/monitoring/v1"
+	"github.com/grafana-tools/sdk"
+	"gomodules.xyz/pointer"
+	crd_cs "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	appcat_cs "kmodules.xyz/custom-resources/client/clientset/versioned/typed/appcatalog/v1alpha1"
+)
+
+// GrafanaController reconciles Dashboard and Datasource custom resources against
+// one or more Grafana instances referenced by AppBinding.
+type GrafanaController struct {
+	config
+
+	clientConfig     *rest.Config
+	kubeClient       kubernetes.Interface
+	extClient        cs.Interface
+	crdClient        crd_cs.Interface
+	appCatalogClient appcat_cs.AppcatalogV1alpha1Interface
+	promClient       pcm.MonitoringV1Interface
+
+	kubeInformerFactory informers.SharedInformerFactory
+	extInformerFactory  grafanainformers.SharedInformerFactory
+	recorder            record.EventRecorder
+
+	dashboardInformer cache.SharedIndexInformer
+	dashboardLister   listers.DashboardLister
+
+	datasourceInformer cache.SharedIndexInformer
+	datasourceLister   listers.DatasourceLister
+
+	// grafanaClient is a single, namespace-agnostic Grafana client. Nothing in
+	// this package reads it; every reconciler resolves its client through
+	// grafanaPool.
+	//
+	// Deprecated: use grafanaPool instead.
+	grafanaClient *sdk.Client
+
+	// grafanaPool lazily builds and caches one sdk.Client per AppBinding so that
+	// reconciles against different Grafana instances don't share state or a queue.
+	grafanaPool *grafanaClientPool
+}
+
+// patchOptions returns the metav1.PatchOptions every server-side apply call in
+// this package shares, with Force set from the controller-wide
+// ForceConflictResolution flag.
+func (c *GrafanaController) patchOptions() metav1.PatchOptions {
+	return metav1.PatchOptions{Force: pointer.BoolP(c.ForceConflictResolution)}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+
+	api "go.searchlight.dev/grafana-operator/apis/grafana/v1alpha1"
+	"go.searchlight.dev/grafana-operator/client/clientset/versioned/typed/grafana/v1alpha1/util"
+
+	"github.com/golang/glog"
+	"github.com/grafana-tools/sdk"
+	"gomodules.xyz/pointer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	core_util "kmodules.xyz/client-go/core/v1"
+)
+
+const (
+	DashboardFinalizer = "dashboard.grafana.searchlight.dev"
+)
+
+func (c *GrafanaController) initDashboardWatcher() {
+	c.dashboardInformer = c.extInformerFactory.Grafana().V1alpha1().Dashboards().Informer()
+	c.dashboardLister = c.extInformerFactory.Grafana().V1alpha1().Dashboards().Lister()
+	c.dashboardInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueueDashboard(obj) },
+		UpdateFunc: func(_, obj interface{}) { c.enqueueDashboard(obj) },
+		DeleteFunc: func(obj interface{}) { c.enqueueDashboard(obj) },
+	})
+}
+
+// enqueueDashboard routes a Dashboard's key onto the per-AppBinding workqueue
+// of the Grafana instance it targets, instead of a single queue shared by
+// every Dashboard in the cluster. This keeps a slow or wedged Grafana
+// instance from starving reconciles against a different one.
+func (c *GrafanaController) enqueueDashboard(obj interface{}) {
+	dash, ok := obj.(*api.Dashboard)
+	if !ok {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			dash, ok = tombstone.Obj.(*api.Dashboard)
+			if !ok {
+				glog.Errorf("error decoding object tombstone, invalid type %T", tombstone.Obj)
+				return
+			}
+		} else {
+			glog.Errorf("error decoding object, invalid type %T", obj)
+			return
+		}
+	}
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(dash)
+	if err != nil {
+		glog.Errorf("failed to compute key for Dashboard %s/%s: %v", dash.Namespace, dash.Name, err)
+		return
+	}
+	grafanaKey := poolKey(dash.Namespace, dash.Spec.Grafana)
+	c.grafanaPool.QueueFor(grafanaKey, c.runDashboardInjector).GetQueue().Add(key)
+}
+
+func (c *GrafanaController) runDashboardInjector(key string) error {
+	obj, exists, err := c.dashboardInformer.GetIndexer().GetByKey(key)
+	if err != nil {
+		glog.Errorf("Fetching object with key %s from store failed with %v", key, err)
+		return err
+	}
+	if !exists {
+		glog.Warningf("Dashboard %s does not exist anymore\n", key)
+	} else {
+		dash := obj.(*api.Dashboard).DeepCopy()
+		glog.Infof("Sync/Add/Update for Dashboard %s/%s\n", dash.Namespace, dash.Name)
+		client, err := c.grafanaPool.ClientForAppBinding(dash.Namespace, dash.Spec.Grafana)
+		if err != nil {
+			return err
+		}
+		err = c.reconcileDashboard(client, dash)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *GrafanaController) reconcileDashboard(client *sdk.Client, dash *api.Dashboard) error {
+	if dash.DeletionTimestamp != nil {
+		if core_util.HasFinalizer(dash.ObjectMeta, DashboardFinalizer) {
+			err := c.runDashboardFinalizer(client, dash)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if !core_util.HasFinalizer(dash.ObjectMeta, DashboardFinalizer) {
+		// Add Finalizer
+		_, _, err := util.PatchDashboard(context.TODO(), c.extClient.GrafanaV1alpha1(), dash, func(up *api.Dashboard) *api.Dashboard {
+			up.ObjectMeta = core_util.AddFinalizer(dash.ObjectMeta, DashboardFinalizer)
+			return up
+		}, c.patchOptions())
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	board := sdk.Board{}
+	if err := board.UnmarshalJSON(dash.Spec.Model.Raw); err != nil {
+		return err
+	}
+	if dash.Status.DashboardID != nil {
+		board.ID = uint(pointer.Int64(dash.Status.DashboardID))
+	}
+
+	statusMsg, err := client.SetDashboard(context.TODO(), board, sdk.SetDashboardParams{Overwrite: true})
+	if err != nil {
+		return err
+	}
+	glog.Infof("Dashboard is set with message: %s\n", pointer.String(statusMsg.Message))
+	_, err = util.UpdateDashboardStatus(context.TODO(), c.extClient.GrafanaV1alpha1(), dash, func(status *api.DashboardStatus) *api.DashboardStatus {
+		if statusMsg.ID != nil {
+			status.DashboardID = pointer.Int64P(int64(pointer.Uint(statusMsg.ID)))
+		}
+		if statusMsg.UID != nil {
+			status.DashboardUID = statusMsg.UID
+		}
+		return status
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *GrafanaController) runDashboardFinalizer(client *sdk.Client, dash *api.Dashboard) error {
+	if dash.Status.DashboardUID == nil {
+		return errors.New("dashboard can't be deleted: reason: Dashboard UID is missing")
+	}
+	statusMsg, err := client.DeleteDashboard(context.TODO(), pointer.String(dash.Status.DashboardUID))
+	if err != nil {
+		return err
+	}
+	glog.Infof("Dashboard is deleted with message: %s\n", pointer.String(statusMsg.Message))
+
+	// remove Finalizer
+	_, _, err = util.PatchDashboard(context.TODO(), c.extClient.GrafanaV1alpha1(), dash, func(up *api.Dashboard) *api.Dashboard {
+		up.ObjectMeta = core_util.RemoveFinalizer(dash.ObjectMeta, DashboardFinalizer)
+		return up
+	}, c.patchOptions())
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -26,9 +26,10 @@ import (
 	"github.com/golang/glog"
 	"github.com/grafana-tools/sdk"
 	"gomodules.xyz/pointer"
+	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
 	core_util "kmodules.xyz/client-go/core/v1"
-	"kmodules.xyz/client-go/tools/queue"
 )
 
 const (
@@ -37,9 +38,55 @@ const (
 
 func (c *GrafanaController) initDatasourceWatcher() {
 	c.datasourceInformer = c.extInformerFactory.Grafana().V1alpha1().Datasources().Informer()
-	c.datasourceQueue = queue.New(api.ResourceKindDatasource, c.MaxNumRequeues, c.NumThreads, c.runDatasourceInjector)
-	c.datasourceInformer.AddEventHandler(queue.NewReconcilableHandler(c.datasourceQueue.GetQueue()))
 	c.datasourceLister = c.extInformerFactory.Grafana().V1alpha1().Datasources().Lister()
+	c.datasourceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueueDatasource(obj) },
+		UpdateFunc: func(_, obj interface{}) { c.enqueueDatasource(obj) },
+		DeleteFunc: func(obj interface{}) { c.enqueueDatasource(obj) },
+	})
+
+	// Watching Secrets lets credential rotations (basic auth, TLS, bearer
+	// tokens) propagate to Grafana without waiting for the next Datasource
+	// spec change.
+	c.kubeInformerFactory.Core().V1().Secrets().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if secret, ok := obj.(*core.Secret); ok {
+				c.enqueueDatasourcesForSecret(secret)
+			}
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			if secret, ok := obj.(*core.Secret); ok {
+				c.enqueueDatasourcesForSecret(secret)
+			}
+		},
+	})
+}
+
+// enqueueDatasource routes a Datasource's key onto the per-AppBinding workqueue
+// of the Grafana instance it targets, instead of a single queue shared by every
+// Datasource in the cluster. This keeps a slow or wedged Grafana instance from
+// starving reconciles against a different one.
+func (c *GrafanaController) enqueueDatasource(obj interface{}) {
+	ds, ok := obj.(*api.Datasource)
+	if !ok {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			ds, ok = tombstone.Obj.(*api.Datasource)
+			if !ok {
+				glog.Errorf("error decoding object tombstone, invalid type %T", tombstone.Obj)
+				return
+			}
+		} else {
+			glog.Errorf("error decoding object, invalid type %T", obj)
+			return
+		}
+	}
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(ds)
+	if err != nil {
+		glog.Errorf("failed to compute key for Datasource %s/%s: %v", ds.Namespace, ds.Name, err)
+		return
+	}
+	grafanaKey := poolKey(ds.Namespace, ds.Spec.Grafana)
+	c.grafanaPool.QueueFor(grafanaKey, c.runDatasourceInjector).GetQueue().Add(key)
 }
 
 func (c *GrafanaController) runDatasourceInjector(key string) error {
@@ -53,11 +100,11 @@ func (c *GrafanaController) runDatasourceInjector(key string) error {
 	} else {
 		ds := obj.(*api.Datasource).DeepCopy()
 		glog.Infof("Sync/Add/Update for Datasource %s/%s\n", ds.Namespace, ds.Name)
-		err := c.setGrafanaClient(ds.Namespace, ds.Spec.Grafana)
+		client, err := c.grafanaPool.ClientForAppBinding(ds.Namespace, ds.Spec.Grafana)
 		if err != nil {
 			return err
 		}
-		err = c.reconcileDatasource(ds)
+		err = c.reconcileDatasource(client, ds)
 		if err != nil {
 			return err
 		}
@@ -65,10 +112,10 @@ func (c *GrafanaController) runDatasourceInjector(key string) error {
 	return nil
 }
 
-func (c *GrafanaController) reconcileDatasource(ds *api.Datasource) error {
+func (c *GrafanaController) reconcileDatasource(client *sdk.Client, ds *api.Datasource) error {
 	if ds.DeletionTimestamp != nil {
 		if core_util.HasFinalizer(ds.ObjectMeta, DatasourceFinalizer) {
-			err := c.runDatasourceFinalizer(ds)
+			err := c.runDatasourceFinalizer(client, ds)
 			if err != nil {
 				return err
 			}
@@ -80,52 +127,66 @@ func (c *GrafanaController) reconcileDatasource(ds *api.Datasource) error {
 		_, _, err := util.PatchDatasource(context.TODO(), c.extClient.GrafanaV1alpha1(), ds, func(up *api.Datasource) *api.Datasource {
 			up.ObjectMeta = core_util.AddFinalizer(ds.ObjectMeta, DatasourceFinalizer)
 			return up
-		}, metav1.PatchOptions{})
+		}, c.patchOptions())
 		if err != nil {
 			return err
 		}
 		return nil
 	}
+	jsonData := map[string]interface{}{}
+	secureJSONData := map[string]interface{}{}
+	basicAuthUser, basicAuthPassword, err := c.resolveDatasourceAuth(ds, jsonData, secureJSONData)
+	if err != nil {
+		return err
+	}
+
 	dataSrc := sdk.Datasource{
-		OrgID:     uint(ds.Spec.OrgID),
-		Name:      ds.Spec.Name,
-		Type:      ds.Spec.Type,
-		Access:    ds.Spec.Access,
-		URL:       ds.Spec.URL,
-		IsDefault: ds.Spec.IsDefault,
+		OrgID:             uint(ds.Spec.OrgID),
+		Name:              ds.Spec.Name,
+		Type:              ds.Spec.Type,
+		Access:            ds.Spec.Access,
+		URL:               ds.Spec.URL,
+		IsDefault:         ds.Spec.IsDefault,
+		BasicAuth:         basicAuthUser != "" || basicAuthPassword != "",
+		BasicAuthUser:     basicAuthUser,
+		BasicAuthPassword: basicAuthPassword,
+		JSONData:          jsonData,
+		SecureJSONData:    secureJSONData,
 	}
 
 	if ds.Status.DatasourceID != nil {
 		dataSrc.ID = uint(pointer.Int64(ds.Status.DatasourceID))
 
-		statusMsg, err := c.grafanaClient.UpdateDatasource(context.TODO(), dataSrc)
+		statusMsg, err := client.UpdateDatasource(context.TODO(), dataSrc)
 		if err != nil {
 			return err
 		}
 		glog.Infof("Datasource is updated with message: %s\n", pointer.String(statusMsg.Message))
 		return nil
 	}
-	statusMsg, err := c.grafanaClient.CreateDatasource(context.TODO(), dataSrc)
+	statusMsg, err := client.CreateDatasource(context.TODO(), dataSrc)
 	if err != nil {
 		return err
 	}
 	glog.Infof("Datasource is created with message: %s\n", pointer.String(statusMsg.Message))
-	if statusMsg.ID != nil {
-		ds.Status.DatasourceID = pointer.Int64P(int64(pointer.Uint(statusMsg.ID)))
-	}
-	_, err = c.extClient.GrafanaV1alpha1().Datasources(ds.Namespace).UpdateStatus(context.TODO(), ds, metav1.UpdateOptions{})
+	_, err = util.UpdateDatasourceStatus(context.TODO(), c.extClient.GrafanaV1alpha1(), ds, func(status *api.DatasourceStatus) *api.DatasourceStatus {
+		if statusMsg.ID != nil {
+			status.DatasourceID = pointer.Int64P(int64(pointer.Uint(statusMsg.ID)))
+		}
+		return status
+	})
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (c *GrafanaController) runDatasourceFinalizer(ds *api.Datasource) error {
+func (c *GrafanaController) runDatasourceFinalizer(client *sdk.Client, ds *api.Datasource) error {
 	if ds.Status.DatasourceID == nil {
 		return errors.New("datasource can't be deleted: reason: Datasource ID is missing")
 	}
 	dsID := uint(pointer.Int64(ds.Status.DatasourceID))
-	statusMsg, err := c.grafanaClient.DeleteDatasource(context.TODO(), dsID)
+	statusMsg, err := client.DeleteDatasource(context.TODO(), dsID)
 	if err != nil {
 		return err
 	}
@@ -135,7 +196,7 @@ func (c *GrafanaController) runDatasourceFinalizer(ds *api.Datasource) error {
 	_, _, err = util.PatchDatasource(context.TODO(), c.extClient.GrafanaV1alpha1(), ds, func(up *api.Datasource) *api.Datasource {
 		up.ObjectMeta = core_util.RemoveFinalizer(ds.ObjectMeta, DatasourceFinalizer)
 		return up
-	}, metav1.PatchOptions{})
+	}, c.patchOptions())
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,86 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	api "go.searchlight.dev/grafana-operator/apis/grafana/v1alpha1"
+	"go.searchlight.dev/grafana-operator/pkg/datasourceauth"
+
+	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// resolveDatasourceAuth fetches every Secret a Datasource's SecretRef points to
+// and fills in the basicAuthUser/basicAuthPassword, TLS and SecureJsonData/
+// JsonData fields Grafana's HTTP API expects, via the shared
+// datasourceauth.Resolve logic. A nil SecretRef is a no-op.
+func (c *GrafanaController) resolveDatasourceAuth(ds *api.Datasource, jsonData, secureJSONData map[string]interface{}) (basicAuthUser, basicAuthPassword string, err error) {
+	get := func(secretName, key string) (string, error) {
+		secret, err := c.kubeClient.CoreV1().Secrets(ds.Namespace).Get(secretName, metav1.GetOptions{})
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to get Secret %s/%s for Datasource %s", ds.Namespace, secretName, ds.Name)
+		}
+		val, ok := secret.Data[key]
+		if !ok {
+			return "", datasourceauth.WrapSecretNotFound(ds.Namespace, secretName, key)
+		}
+		return string(val), nil
+	}
+	return datasourceauth.Resolve(ds.Spec.SecretRef, get, jsonData, secureJSONData)
+}
+
+// enqueueDatasourcesForSecret finds every Datasource in secret's namespace whose
+// SecretRef points at it and enqueues them, so credential rotations propagate
+// without waiting for the next spec change.
+func (c *GrafanaController) enqueueDatasourcesForSecret(secret *core.Secret) {
+	list, err := c.datasourceLister.Datasources(secret.Namespace).List(labels.Everything())
+	if err != nil {
+		return
+	}
+	for _, ds := range list {
+		if datasourceReferencesSecret(ds.Spec.SecretRef, secret.Name) {
+			c.enqueueDatasource(ds)
+		}
+	}
+}
+
+func datasourceReferencesSecret(ref *api.DatasourceSecretRef, name string) bool {
+	if ref == nil {
+		return false
+	}
+	selectors := []*api.SecretKeySelector{
+		ref.BasicAuthUserSecretRef,
+		ref.BasicAuthPasswordSecretRef,
+		ref.TLSClientCertSecretRef,
+		ref.TLSClientKeySecretRef,
+		ref.TLSCASecretRef,
+		ref.BearerTokenSecretRef,
+	}
+	for _, sel := range selectors {
+		if sel != nil && sel.Name == name {
+			return true
+		}
+	}
+	for i := range ref.HTTPHeaderSecretRefs {
+		if ref.HTTPHeaderSecretRefs[i].Name == name {
+			return true
+		}
+	}
+	return false
+}
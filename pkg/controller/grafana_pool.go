@@ -0,0 +1,248 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/grafana-tools/sdk"
+	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	appcat "kmodules.xyz/custom-resources/apis/appcatalog/v1alpha1"
+	"kmodules.xyz/client-go/tools/queue"
+)
+
+// grafanaEntry is one AppBinding's cached client plus the resource version of the
+// Secret it was built from, so callers can tell when credentials have rotated.
+type grafanaEntry struct {
+	client        *sdk.Client
+	appBindingUID string
+	secretVersion string
+}
+
+// poolQueue pairs a per-AppBinding workqueue with the stop channel its worker
+// goroutine was started with, so Evict can shut the goroutine down instead of
+// just forgetting about it.
+type poolQueue struct {
+	worker *queue.Worker
+	stopCh chan struct{}
+}
+
+// grafanaClientPool lazily builds and caches one sdk.Client per
+// "<namespace>/<appbinding-name>" key, each backed by its own rate-limited
+// workqueue. This keeps a slow or wedged Grafana instance from starving
+// reconciles against a different one.
+type grafanaClientPool struct {
+	ctrl *GrafanaController
+
+	mu      sync.RWMutex
+	entries map[string]*grafanaEntry
+	queues  map[string]*poolQueue
+}
+
+func newGrafanaClientPool(ctrl *GrafanaController) *grafanaClientPool {
+	return &grafanaClientPool{
+		ctrl:    ctrl,
+		entries: map[string]*grafanaEntry{},
+		queues:  map[string]*poolQueue{},
+	}
+}
+
+// poolKey builds the "<namespace>/<appbinding-name>" key a Dashboard/Datasource's
+// Spec.Grafana reference resolves to.
+func poolKey(namespace, appBindingName string) string {
+	return namespace + "/" + appBindingName
+}
+
+// splitPoolKey reverses poolKey.
+func splitPoolKey(key string) (namespace, appBindingName string) {
+	for i := 0; i < len(key); i++ {
+		if key[i] == '/' {
+			return key[:i], key[i+1:]
+		}
+	}
+	return "", key
+}
+
+// QueueFor returns the per-AppBinding workqueue for key, creating and starting it
+// on first use with its own stop channel, closed by Evict so the worker
+// goroutine doesn't leak when the AppBinding behind key goes away. handler is
+// only used the first time QueueFor is called for a given key.
+func (p *grafanaClientPool) QueueFor(key string, handler func(key string) error) *queue.Worker {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if q, found := p.queues[key]; found {
+		return q.worker
+	}
+	w := queue.New(key, p.ctrl.MaxNumRequeues, p.ctrl.NumThreads, handler)
+	stopCh := make(chan struct{})
+	p.queues[key] = &poolQueue{worker: w, stopCh: stopCh}
+	go w.Run(stopCh)
+	return w
+}
+
+// ClientForAppBinding returns the cached sdk.Client for the given AppBinding,
+// lazily constructing it (and re-reading its Secret) on first use or whenever the
+// backing Secret's resource version has changed since the client was built.
+func (p *grafanaClientPool) ClientForAppBinding(namespace, appBindingName string) (*sdk.Client, error) {
+	key := poolKey(namespace, appBindingName)
+
+	app, err := p.ctrl.appCatalogClient.AppBindings(namespace).Get(appBindingName, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get AppBinding %s", key)
+	}
+
+	secretVersion, err := p.secretVersionFor(namespace, app)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.RLock()
+	entry, found := p.entries[key]
+	p.mu.RUnlock()
+	if found && entry.appBindingUID == string(app.UID) && entry.secretVersion == secretVersion {
+		return entry.client, nil
+	}
+
+	client, err := p.buildClient(namespace, app)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.entries[key] = &grafanaEntry{
+		client:        client,
+		appBindingUID: string(app.UID),
+		secretVersion: secretVersion,
+	}
+	p.mu.Unlock()
+
+	glog.V(3).Infof("grafanaClientPool: (re)built client for %s", key)
+	return client, nil
+}
+
+// secretVersionFor returns the resource version of the Secret an AppBinding
+// points to, used to detect credential rotation.
+func (p *grafanaClientPool) secretVersionFor(namespace string, app *appcat.AppBinding) (string, error) {
+	if app.Spec.Secret == nil {
+		return "", nil
+	}
+	secret, err := p.ctrl.kubeClient.CoreV1().Secrets(namespace).Get(app.Spec.Secret.Name, metav1.GetOptions{})
+	if kerr.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Wrapf(err, "failed to get Secret %s/%s for AppBinding %s", namespace, app.Spec.Secret.Name, app.Name)
+	}
+	return secret.ResourceVersion, nil
+}
+
+func (p *grafanaClientPool) buildClient(namespace string, app *appcat.AppBinding) (*sdk.Client, error) {
+	url, err := app.URL()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to resolve URL from AppBinding %s/%s", namespace, app.Name)
+	}
+
+	if app.Spec.Secret != nil {
+		secret, err := p.ctrl.kubeClient.CoreV1().Secrets(namespace).Get(app.Spec.Secret.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get Secret %s/%s for AppBinding %s", namespace, app.Spec.Secret.Name, app.Name)
+		}
+		if token, ok := secret.Data["token"]; ok {
+			return sdk.NewClient(url, string(token), sdk.DefaultHTTPClient)
+		}
+		if user, ok := secret.Data[core.BasicAuthUsernameKey]; ok {
+			pass := secret.Data[core.BasicAuthPasswordKey]
+			return sdk.NewClient(url, sdk.BasicAuth(string(user), string(pass)), sdk.DefaultHTTPClient)
+		}
+	}
+	return sdk.NewClient(url, "", sdk.DefaultHTTPClient)
+}
+
+// Evict drops the cached client and queue for an AppBinding that no longer
+// exists, so a later reference to the same name rebuilds from scratch instead of
+// reusing a client pointed at a deleted target.
+func (p *grafanaClientPool) Evict(namespace, appBindingName string) {
+	key := poolKey(namespace, appBindingName)
+	p.mu.Lock()
+	delete(p.entries, key)
+	q, found := p.queues[key]
+	delete(p.queues, key)
+	p.mu.Unlock()
+	if found {
+		close(q.stopCh)
+	}
+	glog.V(3).Infof("grafanaClientPool: evicted %s", key)
+}
+
+// HealthCheck pings every cached Grafana instance and evicts the ones that are no
+// longer reachable, returning the keys that failed.
+func (p *grafanaClientPool) HealthCheck() []string {
+	p.mu.RLock()
+	snapshot := make(map[string]*sdk.Client, len(p.entries))
+	for key, entry := range p.entries {
+		snapshot[key] = entry.client
+	}
+	p.mu.RUnlock()
+
+	var unhealthy []string
+	for key, client := range snapshot {
+		if _, err := client.GetHealth(); err != nil {
+			glog.Warningf("grafanaClientPool: health check failed for %s: %v", key, err)
+			unhealthy = append(unhealthy, key)
+			p.mu.Lock()
+			delete(p.entries, key)
+			p.mu.Unlock()
+		}
+	}
+	return unhealthy
+}
+
+// pruneDeletedAppBindings evicts every cached entry whose AppBinding no longer
+// exists, so a stale client isn't kept around (and pinged by HealthCheck)
+// after the AppBinding it was built from is deleted.
+func (p *grafanaClientPool) pruneDeletedAppBindings() {
+	p.mu.RLock()
+	keys := make([]string, 0, len(p.entries))
+	for key := range p.entries {
+		keys = append(keys, key)
+	}
+	p.mu.RUnlock()
+
+	for _, key := range keys {
+		namespace, appBindingName := splitPoolKey(key)
+		_, err := p.ctrl.appCatalogClient.AppBindings(namespace).Get(appBindingName, metav1.GetOptions{})
+		if kerr.IsNotFound(err) {
+			p.Evict(namespace, appBindingName)
+		}
+	}
+}
+
+// StartHealthChecks periodically prunes entries whose AppBinding was deleted
+// and pings every remaining cached client, evicting the ones that have gone
+// unreachable. It blocks, so callers should run it in its own goroutine.
+func (p *grafanaClientPool) StartHealthChecks(interval time.Duration, stopCh <-chan struct{}) {
+	wait.Until(func() {
+		p.pruneDeletedAppBindings()
+		p.HealthCheck()
+	}, interval, stopCh)
+}
@@ -0,0 +1,122 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	api "go.searchlight.dev/grafana-operator/apis/grafana/v1alpha1"
+
+	"github.com/go-logr/logr"
+	"github.com/robfig/cron/v3"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GrafanaBackupReconciler periodically snapshots a Grafana instance's
+// dashboards, datasources, folders and alert rules to a BackupStorage.
+type GrafanaBackupReconciler struct {
+	client.Client
+	Log  logr.Logger
+	Pool *GrafanaClientPool
+}
+
+func (r *GrafanaBackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("grafanabackup", req.NamespacedName)
+
+	var backup api.GrafanaBackup
+	if err := r.Get(ctx, req.NamespacedName, &backup); err != nil {
+		if kerr.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if backup.Spec.Suspend {
+		return ctrl.Result{}, nil
+	}
+
+	schedule, err := cron.ParseStandard(backup.Spec.Schedule)
+	if err != nil {
+		log.Error(err, "invalid backup schedule, not requeueing")
+		return ctrl.Result{}, nil
+	}
+
+	var last time.Time
+	if backup.Status.LastSuccessfulBackupTime != nil {
+		last = backup.Status.LastSuccessfulBackupTime.Time
+	}
+	now := time.Now()
+	next := schedule.Next(last)
+	if now.Before(next) {
+		return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+	}
+
+	if err := r.runBackup(ctx, &backup, now); err != nil {
+		log.Error(err, "backup failed")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: schedule.Next(now).Sub(now)}, nil
+}
+
+func (r *GrafanaBackupReconciler) runBackup(ctx context.Context, backup *api.GrafanaBackup, now time.Time) error {
+	grafanaClient, err := r.Pool.ClientFor(ctx, backup.Namespace, backup.Spec.Grafana)
+	if err != nil {
+		return fmt.Errorf("resolving Grafana client: %w", err)
+	}
+
+	grafanaUID, err := r.Pool.UIDFor(ctx, backup.Namespace, backup.Spec.Grafana)
+	if err != nil {
+		return fmt.Errorf("resolving AppBinding UID: %w", err)
+	}
+
+	archive, err := snapshotGrafana(ctx, grafanaClient, grafanaUID)
+	if err != nil {
+		return fmt.Errorf("snapshotting Grafana instance: %w", err)
+	}
+
+	container, err := openBackupContainer(ctx, r.Client, backup.Namespace, backup.Spec.StorageRef.Name)
+	if err != nil {
+		return fmt.Errorf("resolving BackupStorage: %w", err)
+	}
+
+	key := fmt.Sprintf("%s/%s-%s.tar.gz", backup.Spec.Grafana, backup.Name, now.UTC().Format("20060102T150405Z"))
+	if _, err := container.Put(key, bytes.NewReader(archive), int64(len(archive)), nil); err != nil {
+		return fmt.Errorf("writing snapshot %s: %w", key, err)
+	}
+
+	sum := sha256.Sum256(archive)
+	backup.Status.LastSuccessfulBackupTime = &metav1.Time{Time: now}
+	backup.Status.LastBackupSize = int64(len(archive))
+	backup.Status.LastBackupSHA256 = hex.EncodeToString(sum[:])
+	return r.Status().Update(ctx, backup)
+}
+
+func (r *GrafanaBackupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return builder.ControllerManagedBy(mgr).
+		For(&api.GrafanaBackup{}).
+		Complete(r)
+}
@@ -0,0 +1,142 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/grafana-tools/sdk"
+)
+
+// backupManifest indexes every object written into a snapshot archive so a
+// GrafanaRestore can walk it without re-parsing the whole tarball.
+type backupManifest struct {
+	CreatedAt time.Time          `json:"createdAt"`
+	Index     []backupIndexEntry `json:"index"`
+}
+
+// backupIndexEntry keys one archived object by (grafanaUID, kind, uid) rather
+// than (kind, uid) alone, since a single BackupStorage can be shared by
+// backups of more than one Grafana instance (see resolveSnapshotKey) and two
+// instances can otherwise hand out the same kind/uid pair.
+type backupIndexEntry struct {
+	GrafanaUID string `json:"grafanaUID"`
+	Kind       string `json:"kind"`
+	UID        string `json:"uid"`
+	Path       string `json:"path"`
+}
+
+// snapshotGrafana renders every folder, datasource, dashboard and alert rule
+// on grafanaClient into a tar.gz archive containing a manifest.json index plus
+// one file per object. grafanaUID identifies the AppBinding grafanaClient was
+// built from and is recorded in every index entry. Datasource secrets are
+// never fetched here: datasources are archived as GetAllDatasources returns
+// them, and Grafana's datasource listing leaves out secureJsonData values and
+// knows nothing of the Kubernetes Secrets they were resolved from.
+func snapshotGrafana(ctx context.Context, grafanaClient *sdk.Client, grafanaUID string) ([]byte, error) {
+	manifest := backupManifest{CreatedAt: time.Now()}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	folders, err := grafanaClient.GetAllFolders(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing folders: %w", err)
+	}
+	for _, f := range folders {
+		if err := addSnapshotEntry(tw, &manifest, grafanaUID, "Folder", f.UID, f); err != nil {
+			return nil, err
+		}
+	}
+
+	datasources, err := grafanaClient.GetAllDatasources(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing datasources: %w", err)
+	}
+	for _, ds := range datasources {
+		if err := addSnapshotEntry(tw, &manifest, grafanaUID, "Datasource", fmt.Sprint(ds.ID), ds); err != nil {
+			return nil, err
+		}
+	}
+
+	hits, err := grafanaClient.Search(ctx, sdk.SearchType(sdk.SearchTypeDashboard))
+	if err != nil {
+		return nil, fmt.Errorf("listing dashboards: %w", err)
+	}
+	for _, hit := range hits {
+		board, _, err := grafanaClient.GetDashboardByUID(ctx, hit.UID)
+		if err != nil {
+			return nil, fmt.Errorf("fetching dashboard %s: %w", hit.UID, err)
+		}
+		if err := addSnapshotEntry(tw, &manifest, grafanaUID, "Dashboard", hit.UID, board); err != nil {
+			return nil, err
+		}
+	}
+
+	rules, err := grafanaClient.GetAlertRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing alert rules: %w", err)
+	}
+	for _, rule := range rules {
+		if err := addSnapshotEntry(tw, &manifest, grafanaUID, "AlertRule", rule.UID, rule); err != nil {
+			return nil, err
+		}
+	}
+
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeTarFile(tw, "manifest.json", manifestBytes); err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func addSnapshotEntry(tw *tar.Writer, manifest *backupManifest, grafanaUID, kind, uid string, obj interface{}) error {
+	data, err := json.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := fmt.Sprintf("%s/%s.json", kind, uid)
+	if err := writeTarFile(tw, path, data); err != nil {
+		return err
+	}
+	manifest.Index = append(manifest.Index, backupIndexEntry{GrafanaUID: grafanaUID, Kind: kind, UID: uid, Path: path})
+	return nil
+}
+
+func writeTarFile(tw *tar.Writer, name string, data []byte) error {
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(data))}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
@@ -0,0 +1,47 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	api "go.searchlight.dev/grafana-operator/apis/grafana/v1alpha1"
+
+	"github.com/graymeta/stow"
+	"kmodules.xyz/objectstore-api/osm"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// openBackupContainer resolves a BackupStorage CR into the stow.Container
+// snapshot archives are read from and written to, hiding the S3/GCS/Azure/Local
+// provider differences behind objectstore-api's osm package the same way
+// AppsCode's other backup tooling does.
+func openBackupContainer(ctx context.Context, c client.Client, namespace, name string) (stow.Container, error) {
+	var bs api.BackupStorage
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &bs); err != nil {
+		return nil, err
+	}
+	osmCtx, err := osm.NewOSMContext(ctx, c, bs.Spec.Backend, namespace)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := stow.Dial(osmCtx.Provider, osmCtx.Config)
+	if err != nil {
+		return nil, err
+	}
+	return loc.Container(osmCtx.Container)
+}
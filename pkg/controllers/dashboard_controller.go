@@ -0,0 +1,189 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	api "go.searchlight.dev/grafana-operator/apis/grafana/v1alpha1"
+
+	"github.com/go-logr/logr"
+	"github.com/grafana-tools/sdk"
+	"gomodules.xyz/pointer"
+	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const dashboardFinalizer = "dashboard.grafana.searchlight.dev"
+
+// DashboardReconciler reconciles a Dashboard object.
+type DashboardReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Pool     *GrafanaClientPool
+	Recorder record.EventRecorder
+
+	// ResyncPeriod is the controller-wide default drift-check interval, used
+	// when a Dashboard doesn't set Spec.DriftCheckInterval.
+	ResyncPeriod time.Duration
+}
+
+func (r *DashboardReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("dashboard", req.NamespacedName)
+
+	var dash api.Dashboard
+	if err := r.Get(ctx, req.NamespacedName, &dash); err != nil {
+		if kerr.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if !dash.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(&dash, dashboardFinalizer) {
+			if err := r.finalize(ctx, &dash); err != nil {
+				return ctrl.Result{}, err
+			}
+			controllerutil.RemoveFinalizer(&dash, dashboardFinalizer)
+			if err := r.Update(ctx, &dash); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(&dash, dashboardFinalizer) {
+		controllerutil.AddFinalizer(&dash, dashboardFinalizer)
+		if err := r.Update(ctx, &dash); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	grafanaClient, err := r.Pool.ClientFor(ctx, dash.Namespace, dash.Spec.Grafana)
+	if err != nil {
+		log.Error(err, "failed to resolve Grafana client")
+		return ctrl.Result{}, err
+	}
+
+	board := sdk.Board{}
+	if err := board.UnmarshalJSON(dash.Spec.Model.Raw); err != nil {
+		return ctrl.Result{}, err
+	}
+	if dash.Status.DashboardID != nil {
+		board.ID = uint(*dash.Status.DashboardID)
+	}
+
+	params := sdk.SetDashboardParams{Overwrite: true}
+	statusMsg, err := grafanaClient.SetDashboard(ctx, board, params)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if statusMsg.ID != nil || statusMsg.UID != nil {
+		if statusMsg.ID != nil {
+			dash.Status.DashboardID = pointer.Int64P(int64(pointer.Uint(statusMsg.ID)))
+		}
+		if statusMsg.UID != nil {
+			dash.Status.DashboardUID = statusMsg.UID
+		}
+		if err := r.Status().Update(ctx, &dash); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	interval := driftCheckInterval(dash.Spec.DriftCheckInterval, r.ResyncPeriod)
+	if dueForDriftCheck(dash.Status.LastDriftCheckTime, interval) {
+		if err := r.checkDrift(ctx, log, grafanaClient, &dash); err != nil {
+			log.Error(err, "drift check failed")
+		}
+	}
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// checkDrift fetches the live dashboard Grafana holds for dash.Status.DashboardID
+// and compares it against the rendered spec, recording the result on status and,
+// for DriftPolicyEnforce, re-pushing the desired state.
+func (r *DashboardReconciler) checkDrift(ctx context.Context, log logr.Logger, grafanaClient *sdk.Client, dash *api.Dashboard) error {
+	if dash.Status.DashboardID == nil {
+		return nil
+	}
+
+	live, _, err := grafanaClient.GetDashboardByID(ctx, uint(*dash.Status.DashboardID))
+	if err != nil {
+		return fmt.Errorf("fetching live dashboard: %w", err)
+	}
+
+	desired := sdk.Board{}
+	if err := desired.UnmarshalJSON(dash.Spec.Model.Raw); err != nil {
+		return err
+	}
+	desired.ID = live.ID
+
+	diff, err := diffAgainstSpec(live, desired, "version")
+	if err != nil {
+		return err
+	}
+
+	now := metav1.Now()
+	dash.Status.LastDriftCheckTime = &now
+	dash.Status.Drifted = diff != ""
+	dash.Status.Drift = diff
+
+	if diff != "" {
+		log.Info("drift detected", "diff", diff)
+		if r.Recorder != nil {
+			r.Recorder.Eventf(dash, core.EventTypeWarning, "DriftDetected", "live dashboard diverges from spec: %s", diff)
+		}
+		if dash.Spec.DriftPolicy == api.DriftPolicyEnforce {
+			if _, err := grafanaClient.SetDashboard(ctx, desired, sdk.SetDashboardParams{Overwrite: true}); err != nil {
+				return fmt.Errorf("re-pushing desired dashboard: %w", err)
+			}
+			dash.Status.Drifted = false
+			dash.Status.Drift = ""
+		}
+	}
+
+	return r.Status().Update(ctx, dash)
+}
+
+func (r *DashboardReconciler) finalize(ctx context.Context, dash *api.Dashboard) error {
+	if dash.Status.DashboardUID == nil {
+		return nil
+	}
+	grafanaClient, err := r.Pool.ClientFor(ctx, dash.Namespace, dash.Spec.Grafana)
+	if kerr.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	_, err = grafanaClient.DeleteDashboard(ctx, pointer.String(dash.Status.DashboardUID))
+	return err
+}
+
+func (r *DashboardReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return builder.ControllerManagedBy(mgr).
+		For(&api.Dashboard{}).
+		Complete(r)
+}
@@ -0,0 +1,133 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	api "go.searchlight.dev/grafana-operator/apis/grafana/v1alpha1"
+
+	"github.com/grafana-tools/sdk"
+	"gomodules.xyz/pointer"
+	core "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// renderDatasource builds the sdk.Datasource Grafana's HTTP API expects from a
+// Datasource's spec, without an assigned ID, resolving any Secret-backed auth
+// the spec references. Shared by reconcileDatasourceSpec and drift checking
+// so both compare/push the exact same fields.
+func renderDatasource(ctx context.Context, c client.Client, ds *api.Datasource) (sdk.Datasource, error) {
+	jsonData := map[string]interface{}{}
+	secureJSONData := map[string]interface{}{}
+	basicAuthUser, basicAuthPassword, err := resolveDatasourceAuth(ctx, c, ds, jsonData, secureJSONData)
+	if err != nil {
+		return sdk.Datasource{}, err
+	}
+
+	return sdk.Datasource{
+		OrgID:             uint(ds.Spec.OrgID),
+		Name:              ds.Spec.Name,
+		Type:              ds.Spec.Type,
+		Access:            ds.Spec.Access,
+		URL:               ds.Spec.URL,
+		IsDefault:         ds.Spec.IsDefault,
+		BasicAuth:         basicAuthUser != "" || basicAuthPassword != "",
+		BasicAuthUser:     basicAuthUser,
+		BasicAuthPassword: basicAuthPassword,
+		JSONData:          jsonData,
+		SecureJSONData:    secureJSONData,
+	}, nil
+}
+
+// reconcileDatasourceSpec creates or updates the Grafana-side datasource and
+// records the assigned ID on status. It mirrors pkg/controller's
+// reconcileDatasource, adapted to a client.Client instead of a generated
+// clientset.
+func reconcileDatasourceSpec(ctx context.Context, c client.Client, grafanaClient *sdk.Client, ds *api.Datasource) error {
+	dataSrc, err := renderDatasource(ctx, c, ds)
+	if err != nil {
+		return err
+	}
+
+	if ds.Status.DatasourceID != nil {
+		dataSrc.ID = uint(*ds.Status.DatasourceID)
+		_, err := grafanaClient.UpdateDatasource(ctx, dataSrc)
+		return err
+	}
+
+	statusMsg, err := grafanaClient.CreateDatasource(ctx, dataSrc)
+	if err != nil {
+		return err
+	}
+	if statusMsg.ID != nil {
+		ds.Status.DatasourceID = pointer.Int64P(int64(pointer.Uint(statusMsg.ID)))
+		return c.Status().Update(ctx, ds)
+	}
+	return nil
+}
+
+// secretToDatasourceRequests maps a watched Secret to the Datasources in its
+// namespace that reference it, so rotations enqueue a reconcile without
+// waiting for the next spec change.
+func secretToDatasourceRequests(c client.Client) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		secret, ok := obj.(*core.Secret)
+		if !ok {
+			return nil
+		}
+		var list api.DatasourceList
+		if err := c.List(ctx, &list, client.InNamespace(secret.Namespace)); err != nil {
+			return nil
+		}
+		var reqs []reconcile.Request
+		for i := range list.Items {
+			ds := &list.Items[i]
+			if datasourceReferencesSecret(ds.Spec.SecretRef, secret.Name) {
+				reqs = append(reqs, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(ds)})
+			}
+		}
+		return reqs
+	})
+}
+
+func datasourceReferencesSecret(ref *api.DatasourceSecretRef, name string) bool {
+	if ref == nil {
+		return false
+	}
+	selectors := []*api.SecretKeySelector{
+		ref.BasicAuthUserSecretRef,
+		ref.BasicAuthPasswordSecretRef,
+		ref.TLSClientCertSecretRef,
+		ref.TLSClientKeySecretRef,
+		ref.TLSCASecretRef,
+		ref.BearerTokenSecretRef,
+	}
+	for _, sel := range selectors {
+		if sel != nil && sel.Name == name {
+			return true
+		}
+	}
+	for i := range ref.HTTPHeaderSecretRefs {
+		if ref.HTTPHeaderSecretRefs[i].Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	api "go.searchlight.dev/grafana-operator/apis/grafana/v1alpha1"
+	"go.searchlight.dev/grafana-operator/pkg/datasourceauth"
+
+	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// resolveDatasourceAuth fetches every Secret a Datasource's SecretRef points to
+// and fills in the basicAuthUser/basicAuthPassword, TLS and SecureJsonData/
+// JsonData fields Grafana's HTTP API expects, via the shared
+// datasourceauth.Resolve logic. A nil SecretRef is a no-op.
+func resolveDatasourceAuth(ctx context.Context, c client.Client, ds *api.Datasource, jsonData, secureJSONData map[string]interface{}) (basicAuthUser, basicAuthPassword string, err error) {
+	get := func(secretName, key string) (string, error) {
+		var secret core.Secret
+		if err := c.Get(ctx, client.ObjectKey{Namespace: ds.Namespace, Name: secretName}, &secret); err != nil {
+			return "", errors.Wrapf(err, "failed to get Secret %s/%s for Datasource %s", ds.Namespace, secretName, ds.Name)
+		}
+		val, ok := secret.Data[key]
+		if !ok {
+			return "", datasourceauth.WrapSecretNotFound(ds.Namespace, secretName, key)
+		}
+		return string(val), nil
+	}
+	return datasourceauth.Resolve(ds.Spec.SecretRef, get, jsonData, secureJSONData)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultDriftCheckInterval applies when neither a Dashboard/Datasource nor
+// the controller-wide config sets one.
+const defaultDriftCheckInterval = 10 * time.Minute
+
+// driftCheckInterval resolves the effective interval for comparing a CR's
+// live Grafana state against its spec: a per-CR override wins, then the
+// controller-wide ResyncPeriod, then defaultDriftCheckInterval.
+func driftCheckInterval(override *metav1.Duration, global time.Duration) time.Duration {
+	if override != nil {
+		return override.Duration
+	}
+	if global > 0 {
+		return global
+	}
+	return defaultDriftCheckInterval
+}
+
+// dueForDriftCheck reports whether enough time has passed since last for
+// another drift check against interval.
+func dueForDriftCheck(last *metav1.Time, interval time.Duration) bool {
+	if last == nil {
+		return true
+	}
+	return time.Since(last.Time) >= interval
+}
+
+// canonicalizeJSON round-trips v through encoding/json so object keys sort
+// deterministically, then strips Grafana-populated fields (e.g. "version",
+// "id") that would otherwise always show up as drift.
+func canonicalizeJSON(v interface{}, stripFields ...string) ([]byte, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+	for _, f := range stripFields {
+		delete(m, f)
+	}
+	return json.Marshal(m)
+}
+
+// diffAgainstSpec canonicalizes live and desired, stripping stripFields from
+// both, and returns a JSON-patch-style summary of how live diverges from
+// desired. An empty string means no drift.
+func diffAgainstSpec(live, desired interface{}, stripFields ...string) (string, error) {
+	liveJSON, err := canonicalizeJSON(live, stripFields...)
+	if err != nil {
+		return "", fmt.Errorf("canonicalizing live object: %w", err)
+	}
+	desiredJSON, err := canonicalizeJSON(desired, stripFields...)
+	if err != nil {
+		return "", fmt.Errorf("canonicalizing desired object: %w", err)
+	}
+	patch, err := jsonpatch.CreateMergePatch(liveJSON, desiredJSON)
+	if err != nil {
+		return "", err
+	}
+	if len(patch) == 0 || string(patch) == "{}" {
+		return "", nil
+	}
+	return string(patch), nil
+}
@@ -0,0 +1,61 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import "testing"
+
+func TestCanonicalizeJSONStripsFields(t *testing.T) {
+	v := map[string]interface{}{
+		"title":   "dashboard",
+		"version": 7,
+		"id":      42,
+	}
+	got, err := canonicalizeJSON(v, "version", "id")
+	if err != nil {
+		t.Fatalf("canonicalizeJSON: %v", err)
+	}
+	want := `{"title":"dashboard"}`
+	if string(got) != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestDiffAgainstSpecNoDriftWhenOnlyStrippedFieldsDiffer(t *testing.T) {
+	live := map[string]interface{}{"title": "dashboard", "version": 7}
+	desired := map[string]interface{}{"title": "dashboard", "version": 3}
+
+	patch, err := diffAgainstSpec(live, desired, "version")
+	if err != nil {
+		t.Fatalf("diffAgainstSpec: %v", err)
+	}
+	if patch != "" {
+		t.Errorf("got patch %q, want no drift once version is stripped", patch)
+	}
+}
+
+func TestDiffAgainstSpecReportsRealDrift(t *testing.T) {
+	live := map[string]interface{}{"title": "dashboard", "version": 7}
+	desired := map[string]interface{}{"title": "renamed-dashboard", "version": 3}
+
+	patch, err := diffAgainstSpec(live, desired, "version")
+	if err != nil {
+		t.Fatalf("diffAgainstSpec: %v", err)
+	}
+	if patch == "" {
+		t.Fatal("got no drift, want a patch for the changed title")
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	api "go.searchlight.dev/grafana-operator/apis/grafana/v1alpha1"
+)
+
+// TestDashboardFinalizeNoopWithoutUID guards against finalize ever calling
+// DeleteDashboard with a zero-value UID: without a DashboardUID recorded in
+// status there is nothing to delete, so finalize must return before
+// resolving a Grafana client at all (a nil Pool here would panic if it did).
+func TestDashboardFinalizeNoopWithoutUID(t *testing.T) {
+	r := &DashboardReconciler{}
+	dash := &api.Dashboard{}
+
+	if err := r.finalize(context.Background(), dash); err != nil {
+		t.Fatalf("finalize: %v", err)
+	}
+}
+
+// TestDatasourceFinalizeNoopWithoutID mirrors
+// TestDashboardFinalizeNoopWithoutUID for Datasource: without a DatasourceID
+// recorded in status, finalize must return before resolving a Grafana
+// client.
+func TestDatasourceFinalizeNoopWithoutID(t *testing.T) {
+	r := &DatasourceReconciler{}
+	ds := &api.Datasource{}
+
+	if err := r.finalize(context.Background(), ds); err != nil {
+		t.Fatalf("finalize: %v", err)
+	}
+}
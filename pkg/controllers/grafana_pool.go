@@ -0,0 +1,231 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/grafana-tools/sdk"
+	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	appcat "kmodules.xyz/custom-resources/apis/appcatalog/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// grafanaPoolEntry is one AppBinding's cached client plus the resource version
+// of the Secret it was built from, so callers can tell when credentials have
+// rotated.
+type grafanaPoolEntry struct {
+	client        *sdk.Client
+	appBindingUID string
+	secretVersion string
+}
+
+// GrafanaClientPool is the controller-runtime counterpart of
+// pkg/controller.grafanaClientPool: it lazily builds and caches one sdk.Client
+// per "<namespace>/<appbinding-name>" key, resolved through a client.Client
+// instead of a generated clientset.
+//
+// GrafanaClientPool implements manager.Runnable (via Start), so once a
+// ctrl.Manager exists for this package's reconcilers, wiring its periodic
+// health check/eviction sweep is a plain mgr.Add(pool).
+type GrafanaClientPool struct {
+	client.Client
+
+	healthCheckInterval time.Duration
+
+	mu      sync.RWMutex
+	entries map[string]*grafanaPoolEntry
+}
+
+func NewGrafanaClientPool(c client.Client) *GrafanaClientPool {
+	return &GrafanaClientPool{
+		Client:              c,
+		healthCheckInterval: time.Minute,
+		entries:             map[string]*grafanaPoolEntry{},
+	}
+}
+
+func poolKey(namespace, appBindingName string) string {
+	return namespace + "/" + appBindingName
+}
+
+func splitPoolKey(key string) (namespace, appBindingName string) {
+	for i := 0; i < len(key); i++ {
+		if key[i] == '/' {
+			return key[:i], key[i+1:]
+		}
+	}
+	return "", key
+}
+
+// ClientFor returns the cached sdk.Client for the named AppBinding, lazily
+// constructing it (and re-reading its Secret) on first use or whenever the
+// backing Secret's resource version has changed since the client was built.
+func (p *GrafanaClientPool) ClientFor(ctx context.Context, namespace, appBindingName string) (*sdk.Client, error) {
+	key := poolKey(namespace, appBindingName)
+
+	var app appcat.AppBinding
+	if err := p.Get(ctx, client.ObjectKey{Namespace: namespace, Name: appBindingName}, &app); err != nil {
+		return nil, err
+	}
+
+	secretVersion, err := p.secretVersionFor(ctx, namespace, &app)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.RLock()
+	entry, found := p.entries[key]
+	p.mu.RUnlock()
+	if found && entry.appBindingUID == string(app.UID) && entry.secretVersion == secretVersion {
+		return entry.client, nil
+	}
+
+	built, err := p.buildClient(ctx, namespace, &app)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.entries[key] = &grafanaPoolEntry{
+		client:        built,
+		appBindingUID: string(app.UID),
+		secretVersion: secretVersion,
+	}
+	p.mu.Unlock()
+
+	glog.V(3).Infof("GrafanaClientPool: (re)built client for %s", key)
+	return built, nil
+}
+
+// UIDFor returns the UID of the named AppBinding, used to key snapshot index
+// entries to the Grafana instance they were taken from without tying that
+// identity to the client cache's lifecycle.
+func (p *GrafanaClientPool) UIDFor(ctx context.Context, namespace, appBindingName string) (string, error) {
+	var app appcat.AppBinding
+	if err := p.Get(ctx, client.ObjectKey{Namespace: namespace, Name: appBindingName}, &app); err != nil {
+		return "", err
+	}
+	return string(app.UID), nil
+}
+
+// secretVersionFor returns the resource version of the Secret an AppBinding
+// points to, used to detect credential rotation.
+func (p *GrafanaClientPool) secretVersionFor(ctx context.Context, namespace string, app *appcat.AppBinding) (string, error) {
+	if app.Spec.Secret == nil {
+		return "", nil
+	}
+	var secret core.Secret
+	err := p.Get(ctx, client.ObjectKey{Namespace: namespace, Name: app.Spec.Secret.Name}, &secret)
+	if kerr.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Wrapf(err, "failed to get Secret %s/%s for AppBinding %s", namespace, app.Spec.Secret.Name, app.Name)
+	}
+	return secret.ResourceVersion, nil
+}
+
+func (p *GrafanaClientPool) buildClient(ctx context.Context, namespace string, app *appcat.AppBinding) (*sdk.Client, error) {
+	url, err := app.URL()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to resolve URL from AppBinding %s/%s", namespace, app.Name)
+	}
+
+	auth := ""
+	if app.Spec.Secret != nil {
+		var secret core.Secret
+		if err := p.Get(ctx, client.ObjectKey{Namespace: namespace, Name: app.Spec.Secret.Name}, &secret); err != nil {
+			return nil, err
+		}
+		if token, ok := secret.Data["token"]; ok {
+			auth = string(token)
+		}
+	}
+	return sdk.NewClient(url, auth, sdk.DefaultHTTPClient)
+}
+
+// Evict drops the cached client for an AppBinding that no longer exists, so a
+// later reference to the same name rebuilds from scratch instead of reusing a
+// client pointed at a deleted target.
+func (p *GrafanaClientPool) Evict(namespace, appBindingName string) {
+	key := poolKey(namespace, appBindingName)
+	p.mu.Lock()
+	delete(p.entries, key)
+	p.mu.Unlock()
+	glog.V(3).Infof("GrafanaClientPool: evicted %s", key)
+}
+
+// HealthCheck pings every cached Grafana instance and evicts the ones that are
+// no longer reachable, returning the keys that failed.
+func (p *GrafanaClientPool) HealthCheck() []string {
+	p.mu.RLock()
+	snapshot := make(map[string]*sdk.Client, len(p.entries))
+	for key, entry := range p.entries {
+		snapshot[key] = entry.client
+	}
+	p.mu.RUnlock()
+
+	var unhealthy []string
+	for key, c := range snapshot {
+		if _, err := c.GetHealth(); err != nil {
+			glog.Warningf("GrafanaClientPool: health check failed for %s: %v", key, err)
+			unhealthy = append(unhealthy, key)
+			p.mu.Lock()
+			delete(p.entries, key)
+			p.mu.Unlock()
+		}
+	}
+	return unhealthy
+}
+
+// pruneDeletedAppBindings evicts every cached entry whose AppBinding no longer
+// exists, so a stale client isn't kept around (and pinged by HealthCheck)
+// after the AppBinding it was built from is deleted.
+func (p *GrafanaClientPool) pruneDeletedAppBindings(ctx context.Context) {
+	p.mu.RLock()
+	keys := make([]string, 0, len(p.entries))
+	for key := range p.entries {
+		keys = append(keys, key)
+	}
+	p.mu.RUnlock()
+
+	for _, key := range keys {
+		namespace, appBindingName := splitPoolKey(key)
+		var app appcat.AppBinding
+		err := p.Get(ctx, client.ObjectKey{Namespace: namespace, Name: appBindingName}, &app)
+		if kerr.IsNotFound(err) {
+			p.Evict(namespace, appBindingName)
+		}
+	}
+}
+
+// Start implements manager.Runnable: it periodically prunes entries whose
+// AppBinding was deleted and pings every remaining cached client, evicting the
+// ones that have gone unreachable. It blocks until ctx is done.
+func (p *GrafanaClientPool) Start(ctx context.Context) error {
+	wait.Until(func() {
+		p.pruneDeletedAppBindings(ctx)
+		p.HealthCheck()
+	}, p.healthCheckInterval, ctx.Done())
+	return nil
+}
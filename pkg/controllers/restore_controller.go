@@ -0,0 +1,102 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	api "go.searchlight.dev/grafana-operator/apis/grafana/v1alpha1"
+
+	"github.com/go-logr/logr"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GrafanaRestoreReconciler replays a GrafanaBackup snapshot into a target
+// Grafana instance. A restore is one-shot: once Status.Phase reaches a
+// terminal value the CR is left alone.
+type GrafanaRestoreReconciler struct {
+	client.Client
+	Log  logr.Logger
+	Pool *GrafanaClientPool
+}
+
+func (r *GrafanaRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("grafanarestore", req.NamespacedName)
+
+	var restore api.GrafanaRestore
+	if err := r.Get(ctx, req.NamespacedName, &restore); err != nil {
+		if kerr.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if restore.Status.Phase == api.RestorePhaseSucceeded || restore.Status.Phase == api.RestorePhaseFailed {
+		return ctrl.Result{}, nil
+	}
+
+	grafanaClient, err := r.Pool.ClientFor(ctx, restore.Namespace, restore.Spec.Grafana)
+	if err != nil {
+		log.Error(err, "failed to resolve Grafana client")
+		return ctrl.Result{}, r.setPhase(ctx, &restore, api.RestorePhaseFailed, err.Error())
+	}
+
+	container, err := openBackupContainer(ctx, r.Client, restore.Namespace, restore.Spec.StorageRef.Name)
+	if err != nil {
+		log.Error(err, "failed to resolve BackupStorage")
+		return ctrl.Result{}, r.setPhase(ctx, &restore, api.RestorePhaseFailed, err.Error())
+	}
+
+	key, err := resolveSnapshotKey(container, restore.Spec.Grafana, restore.Spec.Snapshot)
+	if err != nil {
+		log.Error(err, "failed to resolve snapshot")
+		return ctrl.Result{}, r.setPhase(ctx, &restore, api.RestorePhaseFailed, err.Error())
+	}
+
+	manifest, entries, err := readSnapshot(container, key)
+	if err != nil {
+		log.Error(err, "failed to read snapshot")
+		return ctrl.Result{}, r.setPhase(ctx, &restore, api.RestorePhaseFailed, err.Error())
+	}
+
+	if err := applySnapshot(ctx, grafanaClient, manifest, entries); err != nil {
+		log.Error(err, "failed to apply snapshot")
+		return ctrl.Result{}, r.setPhase(ctx, &restore, api.RestorePhaseFailed, err.Error())
+	}
+
+	return ctrl.Result{}, r.setPhase(ctx, &restore, api.RestorePhaseSucceeded, "")
+}
+
+func (r *GrafanaRestoreReconciler) setPhase(ctx context.Context, restore *api.GrafanaRestore, phase api.RestorePhase, reason string) error {
+	restore.Status.Phase = phase
+	restore.Status.Reason = reason
+	if phase == api.RestorePhaseSucceeded {
+		now := metav1.Now()
+		restore.Status.RestoredTime = &now
+	}
+	return r.Status().Update(ctx, restore)
+}
+
+func (r *GrafanaRestoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return builder.ControllerManagedBy(mgr).
+		For(&api.GrafanaRestore{}).
+		Complete(r)
+}
@@ -0,0 +1,130 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package datasourceauth resolves a Datasource's SecretRef into the
+// basicAuthUser/basicAuthPassword, TLS and SecureJsonData/JsonData fields
+// Grafana's HTTP API expects. Both pkg/controller and pkg/controllers need
+// this logic but fetch Secrets through different clients (a generated
+// clientset's typed getter vs. a controller-runtime client.Client), so it
+// lives here as the one copy the pkg/controller and pkg/controllers Datasource
+// reconcilers each adapt a SecretGetter to, instead of drifting as two
+// near-identical copies.
+package datasourceauth
+
+import (
+	"sort"
+	"strconv"
+
+	api "go.searchlight.dev/grafana-operator/apis/grafana/v1alpha1"
+
+	"github.com/pkg/errors"
+)
+
+// SecretGetter returns the value of key within the named Secret in ds's
+// namespace. Callers close over their own client and namespace.
+type SecretGetter func(secretName, key string) (string, error)
+
+// Resolve fetches every Secret ref points to and fills in jsonData/
+// secureJSONData with the TLS/header fields Grafana's HTTP API expects,
+// returning the basic auth user/password separately since those are plain
+// sdk.Datasource fields rather than JSON-data entries. A nil ref is a no-op.
+func Resolve(ref *api.DatasourceSecretRef, get SecretGetter, jsonData, secureJSONData map[string]interface{}) (basicAuthUser, basicAuthPassword string, err error) {
+	if ref == nil {
+		return "", "", nil
+	}
+
+	getSel := func(sel *api.SecretKeySelector) (string, error) {
+		if sel == nil {
+			return "", nil
+		}
+		return get(sel.Name, sel.Key)
+	}
+
+	if basicAuthUser, err = getSel(ref.BasicAuthUserSecretRef); err != nil {
+		return "", "", err
+	}
+	if basicAuthPassword, err = getSel(ref.BasicAuthPasswordSecretRef); err != nil {
+		return "", "", err
+	}
+
+	if cert, err := getSel(ref.TLSClientCertSecretRef); err != nil {
+		return "", "", err
+	} else if cert != "" {
+		secureJSONData["tlsClientCert"] = cert
+		jsonData["tlsAuth"] = true
+	}
+	if key, err := getSel(ref.TLSClientKeySecretRef); err != nil {
+		return "", "", err
+	} else if key != "" {
+		secureJSONData["tlsClientKey"] = key
+	}
+	if ca, err := getSel(ref.TLSCASecretRef); err != nil {
+		return "", "", err
+	} else if ca != "" {
+		secureJSONData["tlsCACert"] = ca
+		jsonData["tlsAuthWithCACert"] = true
+	}
+	if token, err := getSel(ref.BearerTokenSecretRef); err != nil {
+		return "", "", err
+	} else if token != "" {
+		jsonData["httpHeaderName1"] = "Authorization"
+		secureJSONData["httpHeaderValue1"] = "Bearer " + token
+	}
+
+	// ref.HTTPHeaderSecretRefs is a map, whose iteration order is randomized
+	// per run; sort the header names first so the same set of headers always
+	// gets the same httpHeaderNameN/httpHeaderValueN indices instead of
+	// churning Grafana's stored datasource on every reconcile.
+	headers := make([]string, 0, len(ref.HTTPHeaderSecretRefs))
+	for header := range ref.HTTPHeaderSecretRefs {
+		headers = append(headers, header)
+	}
+	sort.Strings(headers)
+	// Header indices continue right after the bearer token's header, if any,
+	// with no gaps, regardless of how many TLS entries secureJSONData holds.
+	idx := 1
+	if _, ok := jsonData[HeaderNameKey(1)]; ok {
+		idx = 2
+	}
+	for _, header := range headers {
+		sel := ref.HTTPHeaderSecretRefs[header]
+		val, err := getSel(&sel)
+		if err != nil {
+			return "", "", err
+		}
+		jsonData[HeaderNameKey(idx)] = header
+		secureJSONData[HeaderValueKey(idx)] = val
+		idx++
+	}
+
+	return basicAuthUser, basicAuthPassword, nil
+}
+
+func HeaderNameKey(idx int) string {
+	return "httpHeaderName" + strconv.Itoa(idx)
+}
+
+func HeaderValueKey(idx int) string {
+	return "httpHeaderValue" + strconv.Itoa(idx)
+}
+
+// WrapSecretNotFound turns a "key not found" condition into an error
+// consistent with the one a Secret-get failure would produce, so callers can
+// build a SecretGetter with errors.Wrapf-quality messages without duplicating
+// this formatting.
+func WrapSecretNotFound(namespace, secretName, key string) error {
+	return errors.Errorf("key %q not found in Secret %s/%s", key, namespace, secretName)
+}
@@ -0,0 +1,73 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datasourceauth
+
+import (
+	"testing"
+
+	api "go.searchlight.dev/grafana-operator/apis/grafana/v1alpha1"
+)
+
+// TestResolveHeaderIndicesAreDeterministic guards against ref.HTTPHeaderSecretRefs'
+// map iteration order leaking into the assigned httpHeaderNameN/httpHeaderValueN
+// indices: the same set of headers must always land on the same indices, or
+// every reconcile would look like a datasource drift to Grafana.
+func TestResolveHeaderIndicesAreDeterministic(t *testing.T) {
+	ref := &api.DatasourceSecretRef{
+		HTTPHeaderSecretRefs: map[string]api.SecretKeySelector{
+			"X-Scope-OrgID": {Name: "org-secret", Key: "org"},
+			"X-Api-Key":     {Name: "key-secret", Key: "key"},
+			"X-Custom":      {Name: "custom-secret", Key: "value"},
+		},
+	}
+	get := func(secretName, key string) (string, error) {
+		return secretName + "/" + key, nil
+	}
+
+	var first map[string]interface{}
+	for i := 0; i < 10; i++ {
+		jsonData := map[string]interface{}{}
+		secureJSONData := map[string]interface{}{}
+		if _, _, err := Resolve(ref, get, jsonData, secureJSONData); err != nil {
+			t.Fatalf("Resolve: %v", err)
+		}
+		if first == nil {
+			first = jsonData
+			continue
+		}
+		for k, v := range first {
+			if jsonData[k] != v {
+				t.Fatalf("run %d: header indices are not deterministic: got %v, want %v", i, jsonData, first)
+			}
+		}
+	}
+
+	// The sorted header order is X-Api-Key, X-Custom, X-Scope-OrgID, so that's
+	// the order indices must be assigned in regardless of map iteration order.
+	jsonData := map[string]interface{}{}
+	secureJSONData := map[string]interface{}{}
+	if _, _, err := Resolve(ref, get, jsonData, secureJSONData); err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	wantOrder := []string{"X-Api-Key", "X-Custom", "X-Scope-OrgID"}
+	for i, header := range wantOrder {
+		idx := i + 1
+		if got := jsonData[HeaderNameKey(idx)]; got != header {
+			t.Errorf("index %d: got header %v, want %s", idx, got, header)
+		}
+	}
+}